@@ -0,0 +1,144 @@
+package strconvert
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BytesEncoding is an encoding of byte slices and byte arrays.
+type BytesEncoding int
+
+const (
+	// Raw uses the bytes as is.
+	Raw BytesEncoding = iota
+	// Hex uses hexadecimal encoding, as in "deadbeef".
+	Hex
+	// Base64Std uses standard, padded base64 encoding as defined in RFC 4648.
+	Base64Std
+	// Base64URL uses unpadded base64 encoding with the URL and file name
+	// safe alphabet as defined in RFC 4648.
+	Base64URL
+	// Base32 uses standard, padded base32 encoding as defined in RFC 4648.
+	Base32
+)
+
+// bytesEncodingNames maps the names of encodings used in struct tags to
+// encodings.
+var bytesEncodingNames = map[string]BytesEncoding{
+	"raw":       Raw,
+	"hex":       Hex,
+	"base64":    Base64Std,
+	"base64url": Base64URL,
+	"base32":    Base32,
+}
+
+// WithBytesEncoding sets the encoding used by [Parse] and [Stringify] for
+// []byte, named byte slice types and byte arrays ([N]byte). By default, byte
+// slices are Raw and byte arrays are Hex.
+//
+// The encoding of a single struct field can be set with one of the options
+// "raw", "hex", "base64", "base64url" or "base32" of its "strconvert" struct
+// tag, as in
+//
+//	Key []byte `strconvert:",hex"`
+//
+// Naming more than one encoding in the tag of a field is an error.
+//
+// When parsing, padding is optional for the base64 and base32 encodings. A
+// byte array must be given exactly as many bytes as it holds, except for the
+// Raw encoding, where shorter inputs leave the remaining bytes zero. Hex
+// encoded [16]byte arrays also accept the UUID forms accepted by ParseUUID.
+func WithBytesEncoding(enc BytesEncoding) func(*Options) {
+	return func(o *Options) {
+		o.bytesEnc = enc
+		o.bytesEncSet = true
+	}
+}
+
+func (enc BytesEncoding) encode(b []byte) string {
+	switch enc {
+	case Hex:
+		return hex.EncodeToString(b)
+	case Base64Std:
+		return base64.StdEncoding.EncodeToString(b)
+	case Base64URL:
+		return base64.RawURLEncoding.EncodeToString(b)
+	case Base32:
+		return base32.StdEncoding.EncodeToString(b)
+	}
+	return string(b)
+}
+
+func (enc BytesEncoding) decode(s string) ([]byte, error) {
+	switch enc {
+	case Hex:
+		return hex.DecodeString(s)
+	case Base64Std:
+		return base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+	case Base64URL:
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	case Base32:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(s, "="))
+	}
+	return []byte(s), nil
+}
+
+// isBytes reports whether values of typ are converted using a bytes
+// encoding.
+func isBytes(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return typ.Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+// bytesEncoding returns the encoding to use for the byte slice or array type
+// typ.
+func bytesEncoding(typ reflect.Type, opts *Options) BytesEncoding {
+	if opts.bytesEncSet || typ.Kind() == reflect.Slice {
+		return opts.bytesEnc
+	}
+	return Hex
+}
+
+func parseBytes(s string, v reflect.Value, opts *Options) error {
+	enc := bytesEncoding(v.Type(), opts)
+	if enc == Hex && v.Kind() == reflect.Array && v.Len() == 16 && len(s) != 32 {
+		u, err := ParseUUID(s)
+		if err != nil {
+			return err
+		}
+		reflect.Copy(v, reflect.ValueOf(u[:]))
+		return nil
+	}
+	b, err := enc.decode(s)
+	if err != nil {
+		return fmt.Errorf("invalid %s bytes: %w", v.Type(), err)
+	}
+	if v.Kind() == reflect.Slice {
+		v.SetBytes(b)
+		return nil
+	}
+	if len(b) > v.Len() || (len(b) < v.Len() && enc != Raw) {
+		return fmt.Errorf("invalid %s: got %d bytes", v.Type(), len(b))
+	}
+	v.Set(reflect.Zero(v.Type()))
+	reflect.Copy(v, reflect.ValueOf(b))
+	return nil
+}
+
+func stringifyBytes(v reflect.Value, opts *Options) string {
+	enc := bytesEncoding(v.Type(), opts)
+	if v.Kind() == reflect.Slice {
+		return enc.encode(v.Bytes())
+	}
+	// Copy, as arrays need not be addressable.
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+	return enc.encode(b)
+}
@@ -0,0 +1,82 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nahojer/strconvert"
+)
+
+type Blob []byte
+
+type Keys struct {
+	_    struct{} `strconvert:",tuple"`
+	ID   [4]byte  `strconvert:",hex"`
+	Key  []byte   `strconvert:",base64"`
+	Name string
+}
+
+func TestBytesEncoding(t *testing.T) {
+	hex := strconvert.WithBytesEncoding(strconvert.Hex)
+	b64 := strconvert.WithBytesEncoding(strconvert.Base64Std)
+	b64url := strconvert.WithBytesEncoding(strconvert.Base64URL)
+	b32 := strconvert.WithBytesEncoding(strconvert.Base32)
+	raw := strconvert.WithBytesEncoding(strconvert.Raw)
+
+	testParse(t, "hello", Blob("hello"))
+	testParse(t, "deadbeef", []byte{0xde, 0xad, 0xbe, 0xef}, hex)
+	testParse(t, "deadbeef", Blob{0xde, 0xad, 0xbe, 0xef}, hex)
+	testParse(t, "deadbeef", [4]byte{0xde, 0xad, 0xbe, 0xef}, hex)
+	testParse(t, "+/8=", []byte{0xfb, 0xff}, b64)
+	testParse(t, "+/8", []byte{0xfb, 0xff}, b64)
+	testParse(t, "-_8", []byte{0xfb, 0xff}, b64url)
+	testParse(t, "-_8=", []byte{0xfb, 0xff}, b64url)
+	testParse(t, "NBSWY3DP", []byte("hello"), b32)
+	testParse(t, "MZXW6===", []byte("foo"), b32)
+	testParse(t, "MZXW6", []byte("foo"), b32)
+	testParse(t, "ab", [4]byte{'a', 'b'}, raw)
+	testParse(t, "DEADBEEF", [4]byte{0xde, 0xad, 0xbe, 0xef})
+	testParse(t, "00ff;0102", [][2]byte{{0x00, 0xff}, {0x01, 0x02}})
+	testParse(t, "00ff;0102", [][]byte{{0x00, 0xff}, {0x01, 0x02}}, hex)
+	testParse(t, "a:00ff", map[string][2]byte{"a": {0x00, 0xff}}, hex)
+	testParse(t, "00010203;AQI;x", Keys{ID: [4]byte{0, 1, 2, 3}, Key: []byte{1, 2}, Name: "x"})
+
+	testStringify(t, Blob("hello"), "hello")
+	testStringify(t, []byte{0xde, 0xad, 0xbe, 0xef}, "deadbeef", hex)
+	testStringify(t, Blob{0xde, 0xad}, "dead", hex)
+	testStringify(t, [4]byte{0xde, 0xad, 0xbe, 0xef}, "deadbeef", hex)
+	testStringify(t, [2]byte{1, 2}, "0102")
+	testStringify(t, []byte{0xfb, 0xff}, "+/8=", b64)
+	testStringify(t, []byte{0xfb, 0xff}, "-_8", b64url)
+	testStringify(t, []byte("foo"), "MZXW6===", b32)
+	testStringify(t, [][]byte{{0x00, 0xff}, {0x01, 0x02}}, "00ff;0102", hex)
+	testStringify(t, Keys{ID: [4]byte{0, 1, 2, 3}, Key: []byte{1, 2}, Name: "x"}, "00010203;AQI=;x")
+	testStringify(t, Keys{Key: []byte{1, 2}}, "00000000;AQI=;", b32)
+
+	for _, tc := range []struct {
+		in  string
+		enc strconvert.BytesEncoding
+	}{
+		{"abc", strconvert.Hex},
+		{"zz", strconvert.Hex},
+		{"!!!!", strconvert.Base64Std},
+		{"0000", strconvert.Base32},
+		{"abcdef", strconvert.Raw},
+	} {
+		var a [2]byte
+		if err := strconvert.Parse(tc.in, reflect.ValueOf(&a).Elem(), strconvert.WithBytesEncoding(tc.enc)); err == nil {
+			t.Errorf("Parse(%q, <[2]byte>, WithBytesEncoding(%d)) = nil; want error", tc.in, tc.enc)
+		}
+	}
+	var a [4]byte
+	if err := strconvert.Parse("00ff", reflect.ValueOf(&a).Elem(), hex); err == nil {
+		t.Errorf("Parse(\"00ff\", <[4]byte>, WithBytesEncoding(Hex)) = nil; want error")
+	}
+
+	var conflicting struct {
+		Key []byte `strconvert:",hex,base64"`
+	}
+	if err := strconvert.SetPath(&conflicting, "key", "00ff"); err == nil {
+		t.Errorf("SetPath(<struct with hex and base64 tags>) = nil; want conflicting encodings error")
+	}
+}
@@ -0,0 +1,206 @@
+package strconvert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	digits    = "0123456789"
+	hexDigits = digits + "abcdefABCDEF"
+	// exprChars are the characters of expressions other than operands. See
+	// WithExpressions.
+	exprChars = "+-*/%<>() \t"
+	// rangeChars are the characters of lists of ranges, which are split on
+	// commas and trimmed of white space. See WithRanges.
+	rangeChars = digits + "+-, \t"
+	// quantityChars are the characters of quantities, including their
+	// suffixes. See WithQuantities.
+	quantityChars = digits + "+-.eEnumkMGTPi"
+	// fileModeChars are the characters of file modes in any of the notations
+	// accepted by parseFileMode.
+	fileModeChars = digits + "o,=+-ugoarwxXst" + fileModeTypeChars
+)
+
+// separator is a separator splitting the input of a value.
+type separator struct {
+	name, sep string
+}
+
+// checkTarget checks that the separators cannot occur in the input of any
+// value of typ that is split on them, so that parsing is unambiguous, and
+// joins any conflicts to the saved error of o. For example, an element
+// separator "-" would split negative numbers of a slice of integers. If typ
+// contains maps, the element and key separators must not overlap either.
+func checkTarget(typ reflect.Type, o *Options) {
+	o.savedErr = errors.Join(o.savedErr, targetConflicts(typ, o))
+}
+
+// targetConflicts returns the conflicts checked by checkTarget, joined into
+// a single error, or nil if there are none. It is used on its own by
+// functions that only convert parts of a value, such as SetPath, to check
+// the parts they convert.
+func targetConflicts(typ reflect.Type, o *Options) error {
+	seen := map[reflect.Type]bool{}
+	errs := checkType(typ, nil, o, seen)
+	for t := range seen {
+		if t.Kind() != reflect.Map && !isOrderedMap(t) {
+			continue
+		}
+		if o.elemSep != "" && o.keySep != "" && overlaps(o.effectiveSep(o.elemSep), o.effectiveSep(o.keySep)) {
+			errs = append(errs, fmt.Errorf("element separator %q and key separator %q overlap", o.elemSep, o.keySep))
+		}
+		break
+	}
+	return errors.Join(errs...)
+}
+
+// checkType checks the values of typ against seps, the separators that split
+// the input containing them, and recursively checks the elements of typ.
+func checkType(typ reflect.Type, seps []separator, o *Options, seen map[reflect.Type]bool) []error {
+	var errs []error
+	if alphabet := inputAlphabet(typ, o); alphabet != "" {
+		for _, s := range seps {
+			if sep := o.effectiveSep(s.sep); strings.Trim(sep, alphabet) == "" {
+				errs = append(errs, fmt.Errorf("%s separator %q conflicts with %s values, which may contain it", s.name, s.sep, typ))
+			}
+		}
+		return errs
+	}
+	if typ.Kind() == reflect.Ptr {
+		return checkType(typ.Elem(), seps, o, seen)
+	}
+	if isLeaf(typ, o) && !isRecord(typ, o) || seen[typ] {
+		return nil
+	}
+	// Check the elements of recursive types once.
+	seen[typ] = true
+
+	elem := []separator{{"element", o.elemSep}}
+	pair := []separator{{"element", o.elemSep}, {"key", o.keySep}}
+	switch {
+	case o.csv:
+		// Elements and keys containing separators are quoted.
+		elem, pair = nil, nil
+	case o.shellWords:
+		// Elements containing separators are quoted.
+		elem, pair = nil, pair[1:]
+	}
+	if isOrderedMap(typ) {
+		key, val := reflect.New(typ).Interface().(orderedMap).entryTypes()
+		errs = append(errs, checkType(key, pair, o, seen)...)
+		return append(errs, checkType(val, elem, o, seen)...)
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return checkType(typ.Elem(), elem, o, seen)
+	case reflect.Map:
+		// Map items are split at their first key separator, so that only
+		// keys may not contain it.
+		errs = append(errs, checkType(typ.Key(), pair, o, seen)...)
+		return append(errs, checkType(typ.Elem(), elem, o, seen)...)
+	case reflect.Struct:
+		// The fields of tuples are split on the element separator, while
+		// those of other structs are converted separately, as by SetPath.
+		fieldSeps := elem
+		if !isTuple(typ) {
+			fieldSeps = nil
+		}
+		for _, f := range structFields(typ) {
+			if _, _, err := tagBytesEncoding(f.Tag.Get("strconvert")); err != nil {
+				errs = append(errs, fmt.Errorf("field %s of %s: %w", f.Name, typ, err))
+			}
+			errs = append(errs, checkType(f.Type, fieldSeps, fieldOptions(f, o), seen)...)
+		}
+	}
+	return errs
+}
+
+// isRecord reports whether typ is a struct other than a tuple that is not
+// converted as a whole, but whose fields may be converted separately, as by
+// SetPath, DecodeHeader and ApplyDefaults.
+func isRecord(typ reflect.Type, o *Options) bool {
+	if typ.Kind() != reflect.Struct || isTuple(typ) {
+		return false
+	}
+	if _, ok := o.funcs[typ]; ok {
+		return false
+	}
+	ptr := reflect.PointerTo(typ)
+	return !ptr.Implements(textUnmarshalerType) && !ptr.Implements(binaryUnmarshalerType)
+}
+
+// inputAlphabet returns the characters that the input of values of typ may
+// consist of, or the empty string if it is not restricted.
+func inputAlphabet(typ reflect.Type, o *Options) string {
+	if _, ok := o.funcs[typ]; ok {
+		return ""
+	}
+	ptr := reflect.PointerTo(typ)
+	if ptr.Implements(textUnmarshalerType) || ptr.Implements(binaryUnmarshalerType) {
+		return ""
+	}
+	if _, ok := o.fixed[typ]; ok {
+		return digits + "+-."
+	}
+	if o.ranges && isRangeType(typ) {
+		if typ.Kind() == reflect.Array {
+			// A single range, as in "1-5".
+			return digits + "+-"
+		}
+		return rangeChars
+	}
+
+	var alphabet string
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		alphabet = hexDigits + "+-_xXoObB"
+		if typ == durationType {
+			alphabet = digits + "+-.nsuµmh"
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		alphabet = hexDigits + "_xXoObB"
+	case reflect.Float32, reflect.Float64:
+		alphabet = hexDigits + "+-._xXpPiInNtTyY"
+	case reflect.Complex64, reflect.Complex128:
+		alphabet = hexDigits + "+-._xXpPiInNtTyY()"
+	case reflect.Bool:
+		return digits + "tTrRuUeEfFaAlLsS"
+	case reflect.Slice, reflect.Array:
+		if isBytes(typ) {
+			return bytesAlphabet(bytesEncoding(typ, o))
+		}
+		return ""
+	default:
+		return ""
+	}
+	switch {
+	case o.quantities && isQuantityType(typ):
+		alphabet = quantityChars
+	case typ == fileModeType:
+		alphabet = fileModeChars
+	}
+	if o.expressions && isExpressionType(typ) {
+		alphabet += exprChars
+	}
+	return alphabet
+}
+
+// bytesAlphabet returns the characters of the encoding enc, or the empty
+// string if it is not restricted.
+func bytesAlphabet(enc BytesEncoding) string {
+	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	switch enc {
+	case Hex:
+		return hexDigits
+	case Base64Std:
+		return letters + strings.ToLower(letters) + digits + "+/="
+	case Base64URL:
+		return letters + strings.ToLower(letters) + digits + "-_="
+	case Base32:
+		return letters + "234567="
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package strconvert_test
+
+import (
+	"io/fs"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestSeparatorConflicts(t *testing.T) {
+	for _, tc := range []struct {
+		v      any
+		optFns []func(*strconvert.Options)
+	}{
+		{&[]int{}, []func(*strconvert.Options){strconvert.WithElementSeparator('-')}},
+		{&[]float64{}, []func(*strconvert.Options){strconvert.WithElementSeparator('.')}},
+		{&[]uint{}, []func(*strconvert.Options){strconvert.WithElementSeparator('b')}},
+		{&[]*int{}, []func(*strconvert.Options){strconvert.WithElementSeparator('-')}},
+		{&[]time.Duration{}, []func(*strconvert.Options){strconvert.WithElementSeparatorString("m")}},
+		{&[]bool{}, []func(*strconvert.Options){strconvert.WithElementSeparatorString("t")}},
+		{&map[float64]string{}, []func(*strconvert.Options){strconvert.WithKeySeparator('.')}},
+		{&map[int]string{}, []func(*strconvert.Options){strconvert.WithKeySeparator('-')}},
+		{&[][2]byte{}, []func(*strconvert.Options){strconvert.WithElementSeparator('a')}},
+		{&[][]byte{}, []func(*strconvert.Options){strconvert.WithElementSeparator('+'), strconvert.WithBytesEncoding(strconvert.Base64Std)}},
+		{&[]int{}, []func(*strconvert.Options){strconvert.WithElementSeparatorString(" "), strconvert.WithExpressions()}},
+		{&[]Cents{}, []func(*strconvert.Options){strconvert.WithElementSeparator('.'), strconvert.WithFixedPoint[Cents](2)}},
+		{&Backend{}, []func(*strconvert.Options){strconvert.WithElementSeparator('.')}},
+		{&strconvert.OrderedMap[int, string]{}, []func(*strconvert.Options){strconvert.WithKeySeparator('-')}},
+		{&[][]int{}, []func(*strconvert.Options){strconvert.WithElementSeparator(','), strconvert.WithRanges()}},
+		{&[][2]int{}, []func(*strconvert.Options){strconvert.WithElementSeparator('-'), strconvert.WithRanges()}},
+		{&[]fs.FileMode{}, []func(*strconvert.Options){strconvert.WithElementSeparator(',')}},
+		{&[]fs.FileMode{}, []func(*strconvert.Options){strconvert.WithElementSeparator('-')}},
+		{&[]int{}, []func(*strconvert.Options){strconvert.WithElementSeparator('k'), strconvert.WithQuantities()}},
+		{&map[string]float64{}, []func(*strconvert.Options){strconvert.WithElementSeparator('m'), strconvert.WithQuantities()}},
+	} {
+		v := reflect.ValueOf(tc.v).Elem()
+		err := strconvert.Parse("", v, tc.optFns...)
+		if err == nil || !strings.Contains(err.Error(), "conflicts with") {
+			t.Errorf("Parse(\"\", <%s>) = %v; want separator conflict", v.Type(), err)
+		}
+		if _, err := strconvert.Stringify(v, tc.optFns...); err == nil {
+			t.Errorf("Stringify(<%s>) = nil; want error", v.Type())
+		}
+	}
+
+	testParse(t, "-1 - -2", []int{-1, -2}, strconvert.WithElementSeparatorString(" - "))
+	testParse(t, "1.5|2.5", []float64{1.5, 2.5}, strconvert.WithElementSeparator('|'))
+	testParse(t, "a.b", []string{"a", "b"}, strconvert.WithElementSeparator('.'))
+	testParse(t, "2-3", []int{2, 3}, strconvert.WithElementSeparator('-'), strconvert.WithParser(strconv.Atoi))
+	testParse(t, "x-1h", map[string]string{"x": "1h"}, strconvert.WithKeySeparator('-'))
+	testParse(t, "a.1.5", map[string]float64{"a": 1.5}, strconvert.WithKeySeparator('.'))
+	testParse(t, "0-2;5", [][]int{{0, 1, 2}, {5}}, strconvert.WithRanges())
+	testParse(t, "u=rw,g=r;o-w", []fs.FileMode{0640, 0}, strconvert.WithElementSeparator(';'))
+	testParse(t, "1Ki 500m", []float64{1024, 0.5}, strconvert.WithElementSeparator(' '), strconvert.WithQuantities())
+}
+
+func TestSeparatorConflicts_EntryPoints(t *testing.T) {
+	type Config struct {
+		Weights []float64
+		Labels  map[string]string
+		Limits  map[string][]int `default:"a:1"`
+	}
+	dot := strconvert.WithElementSeparator('.')
+	dash := strconvert.WithElementSeparator('-')
+
+	var cfg Config
+	for name, err := range map[string]error{
+		"SetPath":       strconvert.SetPath(&cfg, "weights", "1.5.2.5", dot),
+		"ApplySets":     strconvert.ApplySets(&cfg, []string{"weights=1.5.2.5"}, dot),
+		"ApplyDefaults": strconvert.ApplyDefaults(&cfg, dash),
+		"CheckDefaults": strconvert.CheckDefaults(cfg, dash),
+		"Validate": strconvert.Validate(struct {
+			Weights []float64 `validate:"regexp=^[0-9.]+$"`
+		}{}, dot),
+		"DecodeHeader": strconvert.DecodeHeader(http.Header{}, &struct{ M map[string]float64 }{}, dot),
+		"EncodeHeader": strconvert.EncodeHeader(http.Header{}, struct{ M map[string]float64 }{}, dot),
+	} {
+		if err == nil || !strings.Contains(err.Error(), "conflicts with") {
+			t.Errorf("%s(<separator conflict>) = %v; want separator conflict", name, err)
+		}
+	}
+	if _, err := strconvert.GetPath(cfg, "weights", dot); err == nil {
+		t.Errorf("GetPath(<separator conflict>) = nil; want error")
+	}
+	if cfg.Weights != nil {
+		t.Errorf("cfg.Weights = %v; want nil", cfg.Weights)
+	}
+
+	// Header lists are split on commas rather than on the element separator.
+	h := http.Header{"X-Weights": {"1.5,2.5"}}
+	var gw GatewayHeaders
+	if err := strconvert.DecodeHeader(h, &gw, dot); err != nil {
+		t.Errorf("DecodeHeader(%v) = %q; want nil", h, err)
+	}
+	var labels struct{ Labels map[string]string }
+	if err := strconvert.SetPath(&labels, "labels.a", "x.y", dot); err != nil {
+		t.Errorf("SetPath(labels.a) = %q; want nil", err)
+	}
+
+	// Only the values converted are checked, rather than all of cfg.
+	if err := strconvert.SetPath(&cfg, "labels.a", "x.y", dot); err != nil {
+		t.Errorf("SetPath(&cfg, labels.a) = %q; want nil", err)
+	}
+	if err := strconvert.ApplySets(&cfg, []string{"limits.b={1,2}"}, dot); err != nil {
+		t.Errorf("ApplySets(&cfg, limits.b={1,2}) = %q; want nil", err)
+	}
+	if s, err := strconvert.GetPath(cfg, "labels", dot); err != nil || s != "a:x.y" {
+		t.Errorf("GetPath(cfg, labels) = %q, %v; want \"a:x.y\", nil", s, err)
+	}
+	if err := strconvert.Validate(cfg, dot); err != nil {
+		t.Errorf("Validate(cfg) = %q; want nil", err)
+	}
+	// List elements are parsed one at a time, without splitting them.
+	if err := strconvert.ApplySets(&cfg, []string{"weights={1.5,2.5}"}, dot); err != nil {
+		t.Errorf("ApplySets(&cfg, weights={1.5,2.5}) = %q; want nil", err)
+	}
+}
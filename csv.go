@@ -0,0 +1,171 @@
+package strconvert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WithCSVElements makes [Parse] and [Stringify] split and join the elements
+// of slices, arrays, maps and tuples following the quoting rules of CSV
+// fields defined in RFC 4180, rather than splitting on every separator.
+// Elements containing the separator, double quotes or line breaks are
+// enclosed in double quotes, with double quotes doubled, as in
+//
+//	"a, b",c,"d ""quoted"""
+//
+// so that any elements can be stringified and parsed back. The empty string
+// has no elements, while a single empty element is written as "". Map keys
+// containing the key separator are quoted in the same way, as in
+//
+//	"""a:x"":1",b:2
+//
+// WithCSVElements sets the element separator to a comma. Use the
+// WithElementSeparator option after it for another separator. With the
+// WithTrimSpace option, white space around quoted elements is ignored, while
+// white space within the quotes is kept.
+func WithCSVElements() func(*Options) {
+	return func(o *Options) {
+		o.csv = true
+		o.elemSep = ","
+	}
+}
+
+// splitCSV splits s into CSV quoted elements.
+func splitCSV(s string, opts *Options) ([]string, error) {
+	sep := opts.effectiveSep(opts.elemSep)
+	// Do not trim white space separators.
+	trim := opts.trimSpace && strings.TrimSpace(sep) != ""
+	if s == "" || trim && strings.TrimSpace(s) == "" {
+		return []string{}, nil
+	}
+
+	var elems []string
+	for {
+		rest := s
+		if trim {
+			rest = strings.TrimLeft(rest, " \t")
+		}
+		if strings.HasPrefix(rest, `"`) {
+			elem, n, err := readQuoted(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CSV elements %q: %w", s, err)
+			}
+			elems = append(elems, elem)
+			rest = rest[n:]
+			if trim {
+				rest = strings.TrimLeft(rest, " \t")
+			}
+			if rest == "" {
+				return elems, nil
+			}
+			if !strings.HasPrefix(rest, sep) {
+				return nil, fmt.Errorf("invalid CSV elements: unexpected %q after quoted element", rest)
+			}
+			s = rest[len(sep):]
+			continue
+		}
+
+		elem, rest, found := strings.Cut(s, sep)
+		if strings.Contains(elem, `"`) {
+			return nil, fmt.Errorf("invalid CSV elements: bare quote in unquoted element %q", elem)
+		}
+		if opts.trimSpace {
+			elem = strings.TrimSpace(elem)
+		}
+		elems = append(elems, elem)
+		if !found {
+			return elems, nil
+		}
+		s = rest
+	}
+}
+
+// readQuoted reads the quoted element at the start of s, and returns it
+// unquoted along with the number of bytes read.
+func readQuoted(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != '"' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '"' {
+			b.WriteByte('"')
+			i++
+			continue
+		}
+		return b.String(), i + 1, nil
+	}
+	return "", 0, errors.New("missing closing quote")
+}
+
+// joinCSV joins elems, quoting them as needed. It is the inverse operation of
+// splitCSV.
+func joinCSV(elems []string, opts *Options) string {
+	if len(elems) == 1 && elems[0] == "" {
+		// Tell a single empty element from no elements.
+		return `""`
+	}
+	quoted := make([]string, len(elems))
+	for i, e := range elems {
+		quoted[i] = e
+		if needsQuotes(e, opts) {
+			quoted[i] = quoteCSV(e)
+		}
+	}
+	return strings.Join(quoted, opts.elemSep)
+}
+
+func quoteCSV(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func needsQuotes(e string, opts *Options) bool {
+	return strings.Contains(e, opts.elemSep) ||
+		strings.Contains(e, opts.effectiveSep(opts.elemSep)) ||
+		strings.ContainsAny(e, "\"\r\n") ||
+		opts.trimSpace && e != strings.TrimSpace(e)
+}
+
+// quoteKey quotes the stringified map key k if the WithCSVElements option is
+// used and k would not be split from its value at the first key separator
+// otherwise.
+func quoteKey(k string, opts *Options) string {
+	if opts.csv && (strings.Contains(k, opts.keySep) ||
+		strings.Contains(k, opts.effectiveSep(opts.keySep)) ||
+		strings.HasPrefix(k, `"`) ||
+		opts.trimSpace && k != strings.TrimSpace(k)) {
+		return quoteCSV(k)
+	}
+	return k
+}
+
+// splitQuotedPair splits the map item s with a key quoted by quoteKey into
+// its key and value, and reports whether the key of s is quoted. It returns
+// s as a single part if s is not a valid map item.
+func splitQuotedPair(s string, opts *Options) ([]string, bool) {
+	rest := s
+	if opts.trimSpace {
+		rest = strings.TrimLeft(rest, " \t")
+	}
+	if !strings.HasPrefix(rest, `"`) {
+		return nil, false
+	}
+	key, n, err := readQuoted(rest)
+	if err != nil {
+		return []string{s}, true
+	}
+	rest = rest[n:]
+	if opts.trimSpace {
+		rest = strings.TrimLeft(rest, " \t")
+	}
+	val, ok := strings.CutPrefix(rest, opts.effectiveSep(opts.keySep))
+	if !ok {
+		return []string{s}, true
+	}
+	if opts.trimSpace {
+		val = strings.TrimSpace(val)
+	}
+	return []string{key, val}, true
+}
@@ -0,0 +1,76 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nahojer/strconvert"
+)
+
+func TestCSVElements(t *testing.T) {
+	csv := strconvert.WithCSVElements()
+
+	testParse(t, `"a, b",c,"d ""quoted"""`, []string{"a, b", "c", `d "quoted"`}, csv)
+	testParse(t, `a,,"",b`, []string{"a", "", "", "b"}, csv)
+	testParse(t, "", []string{}, csv)
+	testParse(t, " ", []string{}, csv, strconvert.WithTrimSpace())
+	testParse(t, `""`, []string{""}, csv)
+	testParse(t, "\"line\r\nbreak\",x", []string{"line\r\nbreak", "x"}, csv)
+	testParse(t, `1,2,3`, [3]int{1, 2, 3}, csv)
+	testParse(t, `"a:1,2",b:3`, map[string]string{"a": "1,2", "b": "3"}, csv)
+	testParse(t, `"""a:x"":1",b:2:3`, map[string]string{"a:x": "1", "b": "2:3"}, csv)
+	testParse(t, `"""a"""" "" = 1"`, map[string]int{`a" `: 1}, csv, strconvert.WithKeySeparatorString(" = "), strconvert.WithTrimSpace())
+	testParse(t, `"a;b";c`, []string{"a;b", "c"}, csv, strconvert.WithElementSeparator(';'))
+	testParse(t, `"a, b" , c ,  "d "`, []string{"a, b", "c", "d "}, csv, strconvert.WithTrimSpace())
+	testParse(t, `"10.0.0.1",8080`, Backend{Host: "10.0.0.1", Port: 8080}, csv)
+
+	testStringify(t, []string{"a, b", "c", `d "quoted"`}, `"a, b",c,"d ""quoted"""`, csv)
+	testStringify(t, []string{"a", "", "b"}, "a,,b", csv)
+	testStringify(t, []string{}, "", csv)
+	testStringify(t, []string{""}, `""`, csv)
+	testStringify(t, map[string]int{`a" `: 1}, `"""a"""" "" = 1"`, csv, strconvert.WithKeySeparatorString(" = "), strconvert.WithTrimSpace())
+	testStringify(t, map[string]string{"a:x": "1", `"b`: "2"}, `"""""""b"":2","""a:x"":1"`, csv)
+	testStringify(t, []string{"line\nbreak"}, "\"line\nbreak\"", csv)
+	testStringify(t, map[string]string{"a": "1,2", "b": "3"}, `"a:1,2",b:3`, csv)
+	testStringify(t, []string{" x", "y"}, `" x",y`, csv, strconvert.WithTrimSpace())
+	testStringify(t, []string{"a::b"}, `"a::b"`, csv, strconvert.WithElementSeparatorString("::"), strconvert.WithKeySeparatorString("="))
+
+	for _, in := range []string{`"a`, `"a"b`, `a"b`, `"a",b"`} {
+		var s []string
+		if err := strconvert.Parse(in, reflect.ValueOf(&s).Elem(), csv); err == nil {
+			t.Errorf("Parse(%q, <slice>, WithCSVElements()) = nil; want error", in)
+		}
+	}
+	for _, in := range []string{`"""a"""`, `"""a""x:1"`} {
+		var m map[string]string
+		if err := strconvert.Parse(in, reflect.ValueOf(&m).Elem(), csv); err == nil {
+			t.Errorf("Parse(%q, <map>, WithCSVElements()) = nil; want error", in)
+		}
+	}
+
+	for _, orig := range []any{
+		[]string{},
+		[]string{""},
+		[]string{"", ""},
+		map[string]string{"a:x": "1:2", `"q"`: "", "": "e"},
+		map[string][]string{"k:1": {"a", "b,c"}, "k": {}},
+	} {
+		s, err := strconvert.Stringify(reflect.ValueOf(orig), csv)
+		if err != nil {
+			t.Fatalf("Stringify(%q) = \"\", %q", orig, err)
+		}
+		parsed := reflect.New(reflect.TypeOf(orig))
+		if err := strconvert.Parse(s, parsed.Elem(), csv); err != nil {
+			t.Fatalf("Parse(%q) = %q", s, err)
+		}
+		if !cmp.Equal(parsed.Elem().Interface(), orig) {
+			t.Errorf("Parse(Stringify(%q)) = %q, orig %q", orig, parsed.Elem(), orig)
+		}
+	}
+
+	// Elements and keys may contain the separators, so there is no conflict.
+	testParse(t, `"1.5".2`, []float64{1.5, 2}, csv, strconvert.WithElementSeparator('.'))
+	testStringify(t, map[float64]string{1.5: "x"}, `"""1.5"".x"`, csv, strconvert.WithKeySeparator('.'))
+	testParse(t, `"""1.5"".x"`, map[float64]string{1.5: "x"}, csv, strconvert.WithKeySeparator('.'))
+}
@@ -0,0 +1,115 @@
+package strconvert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ApplyDefaults sets every zero-valued exported field of the struct pointed
+// to by dst to the value of its "default" struct tag, as in
+//
+//	Port int `default:"8080"`
+//
+// If dst is not a non-nil pointer to a struct, ApplyDefaults returns an
+// ErrInvalidParseArgument. Nested structs and non-nil pointers to structs are
+// visited recursively. Default values are parsed in the same way as [Parse]
+// would, using the same options.
+//
+// The same defaults are used for fields missing from the input of
+// [DecodeHeader] and for trailing fields omitted from tuples. Use
+// [CheckDefaults] to validate all default values up front.
+func ApplyDefaults(dst any, optFns ...func(*Options)) error {
+	opts, err := optionsFor(optFns, reflect.TypeOf(dst))
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidParseArgument
+	}
+	return applyDefaults(v.Elem(), &opts, map[visit]bool{{v.Pointer(), v.Type()}: true})
+}
+
+func applyDefaults(v reflect.Value, opts *Options, seen map[visit]bool) error {
+	for _, f := range structFields(v.Type()) {
+		fv := v.FieldByIndex(f.Index)
+		if _, ok := f.Tag.Lookup("default"); ok {
+			if fv.IsZero() {
+				if err := setDefault(f, fv, opts); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if fv = followPointers(fv, seen); fv.Kind() == reflect.Struct {
+			if err := applyDefaults(fv, opts, seen); err != nil {
+				return fmt.Errorf("error applying defaults to field %s: %w", f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckDefaults parses the "default" struct tag value of every exported field
+// of the struct type of v (a struct or pointer to struct) into a scratch
+// value, without modifying v, and returns the joined errors of all invalid
+// defaults. Nested struct types are checked recursively.
+//
+// Call CheckDefaults at startup to catch bad default tags before they are
+// first used.
+func CheckDefaults(v any, optFns ...func(*Options)) error {
+	opts, err := optionsFor(optFns, reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+	typ := reflect.TypeOf(v)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return ErrInvalidParseArgument
+	}
+	return errors.Join(checkDefaults(typ, "", map[reflect.Type]bool{}, &opts)...)
+}
+
+func checkDefaults(typ reflect.Type, prefix string, seen map[reflect.Type]bool, opts *Options) []error {
+	if seen[typ] {
+		return nil
+	}
+	seen[typ] = true
+
+	var errs []error
+	for _, f := range structFields(typ) {
+		if def, ok := f.Tag.Lookup("default"); ok {
+			if err := parse(def, reflect.New(f.Type).Elem(), fieldOptions(f, opts)); err != nil {
+				errs = append(errs, fmt.Errorf("invalid default %q for field %s: %w", def, prefix+f.Name, err))
+			}
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			errs = append(errs, checkDefaults(ft, prefix+f.Name+".", seen, opts)...)
+		}
+	}
+	return errs
+}
+
+// setDefault parses the "default" struct tag value of f, if any, into v.
+func setDefault(f reflect.StructField, v reflect.Value, opts *Options) error {
+	def, ok := f.Tag.Lookup("default")
+	if !ok {
+		return nil
+	}
+	fo := *fieldOptions(f, opts)
+	// The default is an input of its own, whose variables are yet to be
+	// expanded.
+	fo.expanded = false
+	if err := parse(def, v, &fo); err != nil {
+		return fmt.Errorf("invalid default %q for field %s: %w", def, f.Name, err)
+	}
+	return nil
+}
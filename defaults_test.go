@@ -0,0 +1,109 @@
+package strconvert_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nahojer/strconvert"
+)
+
+type ServerDefaults struct {
+	Addr    string        `default:"localhost"`
+	Port    uint16        `default:"8080"`
+	Timeout time.Duration `header:"X-Timeout" default:"30s"`
+	Tags    []string      `default:"a;b"`
+	TLS     *struct {
+		Enabled bool `default:"true"`
+	}
+	Limits struct {
+		Burst int `default:"10"`
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	got := ServerDefaults{Addr: "example.com"}
+	got.TLS = &struct {
+		Enabled bool `default:"true"`
+	}{}
+	if err := strconvert.ApplyDefaults(&got); err != nil {
+		t.Fatalf("ApplyDefaults() = %q; want nil", err)
+	}
+	want := ServerDefaults{
+		Addr:    "example.com",
+		Port:    8080,
+		Timeout: 30 * time.Second,
+		Tags:    []string{"a", "b"},
+		TLS:     got.TLS,
+	}
+	want.Limits.Burst = 10
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ApplyDefaults() mismatch (-want +got):\n%s", diff)
+	}
+	if !got.TLS.Enabled {
+		t.Errorf("ApplyDefaults() did not set default of nested pointer field")
+	}
+
+	type Node struct {
+		Name string `default:"node"`
+		Next *Node
+	}
+	n := &Node{}
+	n.Next = &Node{Next: n}
+	if err := strconvert.ApplyDefaults(n); err != nil {
+		t.Fatalf("ApplyDefaults(<cyclic>) = %q; want nil", err)
+	}
+	if n.Name != "node" || n.Next.Name != "node" || n.Next.Next != n {
+		t.Errorf("ApplyDefaults(<cyclic>) = %+v, %+v; want defaults applied once", *n, *n.Next)
+	}
+
+	if err := strconvert.ApplyDefaults(got); !errors.Is(err, strconvert.ErrInvalidParseArgument) {
+		t.Errorf("ApplyDefaults(<struct>) = %v; want ErrInvalidParseArgument", err)
+	}
+}
+
+func TestDecodeDefaults(t *testing.T) {
+	h := http.Header{}
+	h.Set("Port", "9090")
+	var got ServerDefaults
+	if err := strconvert.DecodeHeader(h, &got); err != nil {
+		t.Fatalf("DecodeHeader(%v) = %q; want nil", h, err)
+	}
+	if got.Port != 9090 || got.Timeout != 30*time.Second || got.Addr != "localhost" {
+		t.Errorf("DecodeHeader(%v) = %+v; want defaults for missing headers", h, got)
+	}
+
+	type Endpoint struct {
+		_      struct{} `strconvert:",tuple"`
+		Host   string
+		Port   uint16  `default:"443"`
+		Weight float64 `default:"1"`
+	}
+	testParse(t, "example.com", Endpoint{Host: "example.com", Port: 443, Weight: 1})
+	testParse(t, "example.com;80", Endpoint{Host: "example.com", Port: 80, Weight: 1})
+}
+
+func TestCheckDefaults(t *testing.T) {
+	if err := strconvert.CheckDefaults(ServerDefaults{}); err != nil {
+		t.Errorf("CheckDefaults(ServerDefaults) = %q; want nil", err)
+	}
+
+	type Bad struct {
+		Port   uint16 `default:"-1"`
+		Nested *struct {
+			Timeout time.Duration `default:"soon"`
+		}
+	}
+	err := strconvert.CheckDefaults(&Bad{})
+	if err == nil {
+		t.Fatalf("CheckDefaults(Bad) = nil; want error")
+	}
+	for _, field := range []string{"field Port", "field Nested.Timeout"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("CheckDefaults(Bad) = %q; want error mentioning %s", err, field)
+		}
+	}
+}
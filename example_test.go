@@ -9,19 +9,11 @@ import (
 )
 
 func ExampleWithStringifier() {
-	// Override how float64 values is formatted.
+	// Override how float64 values, and pointers to them, are formatted.
 	strconvert.WithStringifier(func(f float64) (string, error) {
 		return strconv.FormatFloat(f, 'E', 6, 64), nil
 	})
 
-	// Do the same for pointer float64 values.
-	strconvert.WithStringifier(func(f *float64) (string, error) {
-		if f == nil {
-			return strconv.FormatFloat(0, 'E', 6, 64), nil
-		}
-		return strconv.FormatFloat(*f, 'E', 6, 64), nil
-	})
-
 	// Extend Stringify's type support by registering a stringifier for our
 	// custom type.
 	type PrefixedValue struct {
@@ -0,0 +1,317 @@
+package strconvert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxExprShift limits the shift count of shift operations in expressions.
+	maxExprShift = 128
+	// maxExprExponent limits the magnitude of exponents of numbers in
+	// expressions.
+	maxExprExponent = 400
+)
+
+// WithExpressions makes [Parse] evaluate arithmetic expressions for integer,
+// float and [time.Duration] types, so that "64*1024", "1<<20" or "2h+30m"
+// can be used in place of a plain number or duration.
+//
+// Expressions consist of numbers, the binary operators +, -, *, /, %, <<
+// and >>, unary + and -, and parentheses, with the precedence of Go. For
+// durations, operands may also be durations, such as "1h30m"; durations can
+// be added to and subtracted from each other, and multiplied or divided by
+// numbers.
+//
+// Expressions are evaluated exactly, using rational arithmetic. Parse errors
+// if the result does not fit in the target type, or if the target is an
+// integer type and the result is not a whole number. The shift count of
+// shift operations is limited to 128.
+func WithExpressions() func(*Options) {
+	return func(o *Options) {
+		o.expressions = true
+	}
+}
+
+// isExpressionType reports whether values of typ may be parsed from
+// expressions when using the WithExpressions option.
+func isExpressionType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return true
+	}
+	return isInteger(typ.Kind())
+}
+
+// isExpression reports whether s contains any operators, ignoring a leading
+// sign, and is not a literal of typ, so that plain numbers, including those
+// with signed exponents such as "0x1p-2" or "1e-3", are parsed as usual.
+func isExpression(s string, typ reflect.Type) bool {
+	return strings.ContainsAny(strings.TrimLeft(s, "+-"), "+-*/%<>()") && !isLiteral(s, typ)
+}
+
+// isLiteral reports whether s is parsed into values of typ without
+// evaluating it as an expression.
+func isLiteral(s string, typ reflect.Type) bool {
+	var err error
+	switch {
+	case typ == durationType:
+		_, err = time.ParseDuration(s)
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		_, err = strconv.ParseFloat(s, typ.Bits())
+	case isSigned(typ.Kind()):
+		_, err = strconv.ParseInt(s, 0, typ.Bits())
+	default:
+		_, err = strconv.ParseUint(s, 0, typ.Bits())
+	}
+	return err == nil
+}
+
+// parseExpression evaluates the expression s and stores the result in v.
+func parseExpression(s string, v reflect.Value) error {
+	p := exprParser{s: s, durations: v.Type() == durationType}
+	x, err := p.parse()
+	if err != nil {
+		return fmt.Errorf("invalid expression %q: %w", s, err)
+	}
+	if p.durations && !x.dur && x.r.Sign() != 0 {
+		return fmt.Errorf("expression %q is not a duration", s)
+	}
+
+	typ := v.Type()
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, _ := x.r.Float64()
+		if v.OverflowFloat(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("expression %q overflows %s", s, typ)
+		}
+		v.SetFloat(f)
+		return nil
+	}
+
+	if !x.r.IsInt() {
+		return fmt.Errorf("expression %q is not a whole number", s)
+	}
+	n := x.r.Num()
+	if isSigned(typ.Kind()) {
+		if !n.IsInt64() || v.OverflowInt(n.Int64()) {
+			return fmt.Errorf("expression %q overflows %s", s, typ)
+		}
+		v.SetInt(n.Int64())
+	} else {
+		if !n.IsUint64() || v.OverflowUint(n.Uint64()) {
+			return fmt.Errorf("expression %q overflows %s", s, typ)
+		}
+		v.SetUint(n.Uint64())
+	}
+	return nil
+}
+
+// exprValue is the value of a (sub)expression, which is a duration in
+// nanoseconds if dur is true.
+type exprValue struct {
+	r   *big.Rat
+	dur bool
+}
+
+// exprParser is a recursive descent parser evaluating expressions.
+type exprParser struct {
+	s         string
+	pos       int
+	durations bool
+}
+
+func (p *exprParser) parse() (exprValue, error) {
+	x, err := p.parseSum()
+	if err != nil {
+		return x, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return x, fmt.Errorf("unexpected %q", p.s[p.pos:])
+	}
+	return x, nil
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// operator consumes and returns the next operator if it is one of ops.
+func (p *exprParser) operator(ops ...string) string {
+	p.skipSpace()
+	for _, op := range ops {
+		if strings.HasPrefix(p.s[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *exprParser) parseSum() (exprValue, error) {
+	x, err := p.parseProduct()
+	if err != nil {
+		return x, err
+	}
+	for {
+		op := p.operator("+", "-")
+		if op == "" {
+			return x, nil
+		}
+		y, err := p.parseProduct()
+		if err != nil {
+			return x, err
+		}
+		if x.dur != y.dur {
+			return x, errors.New("cannot add durations and numbers")
+		}
+		if op == "+" {
+			x.r = new(big.Rat).Add(x.r, y.r)
+		} else {
+			x.r = new(big.Rat).Sub(x.r, y.r)
+		}
+	}
+}
+
+func (p *exprParser) parseProduct() (exprValue, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return x, err
+	}
+	for {
+		op := p.operator("*", "/", "%", "<<", ">>")
+		if op == "" {
+			return x, nil
+		}
+		y, err := p.parseUnary()
+		if err != nil {
+			return x, err
+		}
+		if x, err = applyProduct(op, x, y); err != nil {
+			return x, err
+		}
+	}
+}
+
+func applyProduct(op string, x, y exprValue) (exprValue, error) {
+	switch op {
+	case "*":
+		if x.dur && y.dur {
+			return x, errors.New("cannot multiply durations")
+		}
+		return exprValue{r: new(big.Rat).Mul(x.r, y.r), dur: x.dur || y.dur}, nil
+
+	case "/":
+		if y.r.Sign() == 0 {
+			return x, errors.New("division by zero")
+		}
+		if y.dur && !x.dur {
+			return x, errors.New("cannot divide numbers by durations")
+		}
+		return exprValue{r: new(big.Rat).Quo(x.r, y.r), dur: x.dur && !y.dur}, nil
+	}
+
+	// Remainder and shifts require whole numbers.
+	if !x.r.IsInt() || !y.r.IsInt() || x.dur || y.dur {
+		return x, fmt.Errorf("operator %s requires whole numbers", op)
+	}
+	a, b := x.r.Num(), y.r.Num()
+	switch op {
+	case "%":
+		if b.Sign() == 0 {
+			return x, errors.New("division by zero")
+		}
+		return exprValue{r: new(big.Rat).SetInt(new(big.Int).Rem(a, b))}, nil
+	}
+	if b.Sign() < 0 || b.Cmp(big.NewInt(maxExprShift)) > 0 {
+		return x, fmt.Errorf("invalid shift count %s", b)
+	}
+	if op == "<<" {
+		return exprValue{r: new(big.Rat).SetInt(new(big.Int).Lsh(a, uint(b.Uint64())))}, nil
+	}
+	return exprValue{r: new(big.Rat).SetInt(new(big.Int).Rsh(a, uint(b.Uint64())))}, nil
+}
+
+func (p *exprParser) parseUnary() (exprValue, error) {
+	switch p.operator("+", "-") {
+	case "+":
+		return p.parseUnary()
+	case "-":
+		x, err := p.parseUnary()
+		if err != nil {
+			return x, err
+		}
+		return exprValue{r: new(big.Rat).Neg(x.r), dur: x.dur}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *exprParser) parseOperand() (exprValue, error) {
+	p.skipSpace()
+	if p.operator("(") != "" {
+		x, err := p.parseSum()
+		if err != nil {
+			return x, err
+		}
+		if p.operator(")") == "" {
+			return x, errors.New("missing )")
+		}
+		return x, nil
+	}
+
+	start := p.pos
+	// Exponents of hexadecimal numbers follow a p, as in "0x1p-2".
+	expChars := "eE"
+	if strings.HasPrefix(p.s[start:], "0x") || strings.HasPrefix(p.s[start:], "0X") {
+		expChars = "pP"
+	}
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		isExpSign := (c == '+' || c == '-') && p.pos > start &&
+			strings.IndexByte(expChars, p.s[p.pos-1]) >= 0
+		if !isExpSign && strings.IndexByte(" \t+-*/%<>()", c) >= 0 {
+			break
+		}
+		p.pos++
+	}
+	tok := p.s[start:p.pos]
+	if tok == "" {
+		if p.pos == len(p.s) {
+			return exprValue{}, errors.New("unexpected end of expression")
+		}
+		return exprValue{}, fmt.Errorf("unexpected %q", p.s[p.pos:])
+	}
+
+	if n, ok := new(big.Int).SetString(tok, 0); ok {
+		return exprValue{r: new(big.Rat).SetInt(n)}, nil
+	}
+	if i := strings.IndexAny(tok, expChars); i >= 0 {
+		// Avoid evaluating huge powers of ten, or of two, whose exponents
+		// are about four times as large for the same magnitude.
+		maxExp := maxExprExponent
+		if expChars == "pP" {
+			maxExp *= 4
+		}
+		if exp, err := strconv.Atoi(tok[i+1:]); err == nil && (exp > maxExp || exp < -maxExp) {
+			return exprValue{}, fmt.Errorf("exponent of %q out of range", tok)
+		}
+	}
+	if r, ok := new(big.Rat).SetString(tok); ok && !strings.Contains(tok, "/") {
+		return exprValue{r: r}, nil
+	}
+	if p.durations {
+		if d, err := time.ParseDuration(tok); err == nil {
+			return exprValue{r: new(big.Rat).SetInt64(int64(d)), dur: true}, nil
+		}
+	}
+	return exprValue{}, fmt.Errorf("invalid operand %q", tok)
+}
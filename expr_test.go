@@ -0,0 +1,77 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestExpressions(t *testing.T) {
+	expr := strconvert.WithExpressions()
+	testParse(t, "64*1024", 65536, expr)
+	testParse(t, "1<<20", uint32(1<<20), expr)
+	testParse(t, "(1 << 10) >> 2", int16(256), expr)
+	testParse(t, "2+3*4", 14, expr)
+	testParse(t, "(2+3)*4", 20, expr)
+	testParse(t, "-(2-5)", int8(3), expr)
+	testParse(t, "10%4+1.5*2", 5, expr)
+	testParse(t, "0x10+0b1+0o7+1_000", 1024, expr)
+	testParse(t, "1e3+1E-3*1000", 1001, expr)
+	testParse(t, "1/3", 1.0/3, expr)
+	testParse(t, "0.1+0.2", float32(0.3), expr)
+	testParse(t, "2h+30m", 2*time.Hour+30*time.Minute, expr)
+	testParse(t, "1h30m - 15m", time.Hour+15*time.Minute, expr)
+	testParse(t, "3*(10s+500ms)", 31500*time.Millisecond, expr)
+	testParse(t, "1h/3", 20*time.Minute, expr)
+	testParse(t, "1m;2*30s", []time.Duration{time.Minute, time.Minute}, expr)
+	testParse(t, "-5", -5, expr)
+	testParse(t, "0x1p-2", 0.25, expr)
+	testParse(t, "-0x1.8p+1", float32(-3), expr)
+	testParse(t, "1e-3", 0.001, expr)
+	testParse(t, "0x1p-2*4", 1.0, expr)
+	testParse(t, "0x1P+4 - 0x10", 0, expr)
+	testParse(t, "0x1e+1", 31, expr)
+	testParse(t, "1<<20", 0, strconvert.WithParser(func(string) (int, error) { return 0, nil }), expr)
+
+	for _, tc := range []struct {
+		in string
+		v  any
+	}{
+		{"1<<8", new(uint8)},
+		{"-1+0", new(uint)},
+		{"1<<63", new(int64)},
+		{"1<<200", new(int)},
+		{"3/2", new(int)},
+		{"1/0", new(int)},
+		{"5%0", new(int)},
+		{"1.5%1", new(int)},
+		{"1<<-1", new(int)},
+		{"(1+2", new(int)},
+		{"1+", new(int)},
+		{"1+2)", new(int)},
+		{"2*x", new(int)},
+		{"1e999+1", new(float64)},
+		{"0x1p9999+1", new(float64)},
+		{"1e39*1", new(float32)},
+		{"1e300*1e300", new(float64)},
+		{"-1e300*1e300", new(float64)},
+		{"1e300*1e300", new(float32)},
+		{"1h*2h", new(time.Duration)},
+		{"1h+1", new(time.Duration)},
+		{"1/1h", new(time.Duration)},
+		{"1h/30m", new(time.Duration)},
+		{"1h<<1", new(time.Duration)},
+		{"1h+1", new(int)},
+	} {
+		if err := strconvert.Parse(tc.in, reflect.ValueOf(tc.v).Elem(), expr); err == nil {
+			t.Errorf("Parse(%q, <%T>, WithExpressions()) = nil; want error", tc.in, tc.v)
+		}
+	}
+
+	var n int
+	if err := strconvert.Parse("64*1024", reflect.ValueOf(&n).Elem()); err == nil {
+		t.Errorf("Parse(\"64*1024\", <int>) = nil; want error without WithExpressions")
+	}
+}
@@ -0,0 +1,112 @@
+package strconvert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// structFields returns the exported fields of the struct type typ in
+// declaration order. Blank fields and fields tagged with `strconvert:"-"`
+// are skipped.
+func structFields(typ reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Name == "_" || f.Tag.Get("strconvert") == "-" {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// isTuple reports whether the struct type typ opts into tuple encoding by
+// declaring a blank field tagged with `strconvert:",tuple"`.
+func isTuple(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.Name == "_" && hasTagOption(f.Tag.Get("strconvert"), "tuple") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTagOption reports whether the comma-separated options following the name
+// in the struct tag value tag contain opt.
+func hasTagOption(tag, opt string) bool {
+	_, opts, _ := strings.Cut(tag, ",")
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldOptions returns the options to use for converting the value of the
+// struct field f, as modified by its struct tags. Conflicting tags are
+// reported by checkTarget rather than here.
+func fieldOptions(f reflect.StructField, opts *Options) *Options {
+	secret := opts.redact && f.Tag.Get("secret") == "true"
+	enc, hasEnc, _ := tagBytesEncoding(f.Tag.Get("strconvert"))
+	if !secret && !hasEnc {
+		return opts
+	}
+	fo := *opts
+	if secret {
+		fo.secret = true
+	}
+	if hasEnc {
+		fo.bytesEnc = enc
+		fo.bytesEncSet = true
+	}
+	return &fo
+}
+
+// tagBytesEncoding returns the bytes encoding named among the options of the
+// struct tag value tag, if any. It errors if more than one encoding is named,
+// and returns the first one.
+func tagBytesEncoding(tag string) (enc BytesEncoding, ok bool, err error) {
+	_, opts, _ := strings.Cut(tag, ",")
+	var first string
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		e, isEnc := bytesEncodingNames[o]
+		if !isEnc {
+			continue
+		}
+		if ok {
+			return enc, ok, fmt.Errorf("conflicting bytes encodings %q and %q", first, o)
+		}
+		enc, ok, first = e, true, o
+	}
+	return enc, ok, nil
+}
+
+// visit is a pointer visited while walking a value. Pointers are identified
+// by their type as well, as a pointer to a struct and a pointer to its first
+// field share the same address.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// followPointers follows the non-nil pointers starting at v and returns the
+// value they point to, or the zero Value if any of them has been visited
+// before, so that cyclic values are walked once.
+func followPointers(v reflect.Value, seen map[visit]bool) reflect.Value {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		k := visit{v.Pointer(), v.Type()}
+		if seen[k] {
+			return reflect.Value{}
+		}
+		seen[k] = true
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,203 @@
+package strconvert
+
+import (
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var fileModeType = reflect.TypeOf(fs.FileMode(0))
+
+// fileModeTypeChars are the characters used by fs.FileMode.String for the
+// type and special bits of a mode, starting with the most significant bit.
+const fileModeTypeChars = "dalTLDpSugct?"
+
+// parseFileMode parses a file mode in octal notation with a leading zero,
+// such as "0644", "0o644" or "04755", in the notation of fs.FileMode.String,
+// such as "-rw-r--r--" or "drwxr-xr-x" (optionally without the leading
+// type), or in the symbolic notation of chmod, such as "u=rw,g=r" or "o-w".
+//
+// Digits without a leading zero, such as "420", are the decimal value of the
+// fs.FileMode, as written by earlier versions of Stringify.
+func parseFileMode(s string) (fs.FileMode, error) {
+	if s == "" {
+		return 0, fmt.Errorf("invalid file mode %q", s)
+	}
+	if s[0] == '0' {
+		u, err := strconv.ParseUint(strings.TrimPrefix(s, "0o"), 8, 32)
+		if err != nil || u > 07777 {
+			return 0, fmt.Errorf("invalid octal file mode %q", s)
+		}
+		return fileModeFromUnix(uint32(u)), nil
+	}
+	if s[0] >= '1' && s[0] <= '9' {
+		u, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid file mode %q", s)
+		}
+		return fs.FileMode(u), nil
+	}
+	if strings.ContainsAny(s, "=+,") || isSymbolicFileMode(s) {
+		return parseSymbolicFileMode(s)
+	}
+	return parseFileModeString(s)
+}
+
+// fileModeFromUnix converts the Unix permission and special bits u, as used
+// by chmod, to a fs.FileMode.
+func fileModeFromUnix(u uint32) fs.FileMode {
+	m := fs.FileMode(u & 0777)
+	if u&04000 != 0 {
+		m |= fs.ModeSetuid
+	}
+	if u&02000 != 0 {
+		m |= fs.ModeSetgid
+	}
+	if u&01000 != 0 {
+		m |= fs.ModeSticky
+	}
+	return m
+}
+
+// fileModeToUnix is the inverse operation of fileModeFromUnix.
+func fileModeToUnix(m fs.FileMode) uint32 {
+	u := uint32(m.Perm())
+	if m&fs.ModeSetuid != 0 {
+		u |= 04000
+	}
+	if m&fs.ModeSetgid != 0 {
+		u |= 02000
+	}
+	if m&fs.ModeSticky != 0 {
+		u |= 01000
+	}
+	return u
+}
+
+func parseFileModeString(s string) (fs.FileMode, error) {
+	if len(s) < 9 {
+		return 0, fmt.Errorf("invalid file mode %q", s)
+	}
+	typ, perm := s[:len(s)-9], s[len(s)-9:]
+
+	var m fs.FileMode
+	if typ != "-" {
+		for _, c := range typ {
+			i := strings.IndexRune(fileModeTypeChars, c)
+			if i < 0 {
+				return 0, fmt.Errorf("invalid file mode %q: unknown type %q", s, c)
+			}
+			m |= 1 << uint(32-1-i)
+		}
+	}
+	const rwx = "rwxrwxrwx"
+	for i := range perm {
+		switch perm[i] {
+		case rwx[i]:
+			m |= 1 << uint(8-i)
+		case '-':
+		default:
+			return 0, fmt.Errorf("invalid file mode %q: unexpected %q", s, perm[i])
+		}
+	}
+	return m, nil
+}
+
+// isSymbolicFileMode reports whether s consists of clauses of the symbolic
+// notation, to tell clauses such as "o-w" from the notation of
+// fs.FileMode.String, which may also contain '-'.
+func isSymbolicFileMode(s string) bool {
+	for _, clause := range strings.Split(s, ",") {
+		rest := strings.TrimLeft(clause, "ugoa")
+		if rest == "" || strings.IndexByte("=+-", rest[0]) < 0 || strings.Trim(rest[1:], "rwxXst") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSymbolicFileMode parses comma-separated clauses of the form
+// [ugoa]*[=+-][rwxXst]*, applied to a mode with no bits set. As there is no
+// file to go by, X sets the execute bit if the clauses before it set any.
+func parseSymbolicFileMode(s string) (fs.FileMode, error) {
+	var u uint32
+	for _, clause := range strings.Split(s, ",") {
+		i := strings.IndexAny(clause, "=+-")
+		if i < 0 {
+			return 0, fmt.Errorf("invalid file mode %q: missing operator in %q", s, clause)
+		}
+		who, op, perms := clause[:i], clause[i], clause[i+1:]
+		if who == "" || strings.Contains(who, "a") {
+			who = "ugo"
+		}
+
+		var mask, bits uint32
+		for _, w := range who {
+			var shift uint32
+			switch w {
+			case 'u':
+				shift = 6
+			case 'g':
+				shift = 3
+			case 'o':
+				shift = 0
+			default:
+				return 0, fmt.Errorf("invalid file mode %q: unknown class %q", s, w)
+			}
+			mask |= 07 << shift
+			for _, p := range perms {
+				switch p {
+				case 'r':
+					bits |= 04 << shift
+				case 'w':
+					bits |= 02 << shift
+				case 'x':
+					bits |= 01 << shift
+				case 'X':
+					if u&0111 != 0 {
+						bits |= 01 << shift
+					}
+				case 's':
+					if w == 'u' {
+						bits |= 04000
+					} else if w == 'g' {
+						bits |= 02000
+					}
+				case 't':
+					bits |= 01000
+				default:
+					return 0, fmt.Errorf("invalid file mode %q: unknown permission %q", s, p)
+				}
+			}
+			switch w {
+			case 'u':
+				mask |= 04000
+			case 'g':
+				mask |= 02000
+			case 'o':
+				mask |= 01000
+			}
+		}
+
+		switch op {
+		case '=':
+			u = u&^mask | bits
+		case '+':
+			u |= bits
+		case '-':
+			u &^= bits
+		}
+	}
+	return fileModeFromUnix(u), nil
+}
+
+// formatFileMode formats m in octal notation with a leading zero, or in the
+// notation of fs.FileMode.String if m has any type bits set.
+func formatFileMode(m fs.FileMode) string {
+	if m&fs.ModeType != 0 || m&^(fs.ModePerm|fs.ModeSetuid|fs.ModeSetgid|fs.ModeSticky) != 0 {
+		return m.String()
+	}
+	return fmt.Sprintf("0%03o", fileModeToUnix(m))
+}
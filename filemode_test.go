@@ -0,0 +1,53 @@
+package strconvert_test
+
+import (
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestFileMode(t *testing.T) {
+	testParse(t, "0644", fs.FileMode(0644))
+	testParse(t, "420", fs.FileMode(0644))
+	testParse(t, "8389101", fs.ModeSetuid|0755)
+	testParse(t, "0o755", os.FileMode(0755))
+	testParse(t, "04755", fs.ModeSetuid|0755)
+	testParse(t, "01777", fs.ModeSticky|0777)
+	testParse(t, "rwxr-xr-x", fs.FileMode(0755))
+	testParse(t, "-rw-r--r--", fs.FileMode(0644))
+	testParse(t, "drwxr-x---", fs.ModeDir|0750)
+	testParse(t, "ugrwxr-xr-x", fs.ModeSetuid|fs.ModeSetgid|0755)
+	testParse(t, "u=rw,g=r", fs.FileMode(0640))
+	testParse(t, "a=r,u+w", fs.FileMode(0644))
+	testParse(t, "u=rwxs,go=rx", fs.ModeSetuid|0755)
+	testParse(t, "a=rwxt,o-w", fs.ModeSticky|0775)
+	testParse(t, "o-w", fs.FileMode(0))
+	testParse(t, "go-rwx", fs.FileMode(0))
+	testParse(t, "a=rwx,go-w", fs.FileMode(0755))
+	testParse(t, "u=rwx,go=rX", fs.FileMode(0755))
+	testParse(t, "a=rX", fs.FileMode(0444))
+	testParse(t, "0600;0644", []fs.FileMode{0600, 0644})
+
+	testStringify(t, fs.FileMode(0644), "0644")
+	testStringify(t, fs.FileMode(0), "0000")
+	testStringify(t, fs.ModeSetgid|0750, "02750")
+	testStringify(t, fs.ModeDir|0755, "drwxr-xr-x")
+	testStringify(t, fs.ModeSymlink|0777, "Lrwxrwxrwx")
+
+	for _, in := range []string{"", "0999", "077777", "4294967296", "0x1ff", "rwxr-xr-", "rwzr-xr-x", "?rwxr-xr-x!", "k=rw", "u=rq", "urw", "o-q", "go-rwx-"} {
+		var m fs.FileMode
+		if err := strconvert.Parse(in, reflect.ValueOf(&m).Elem()); err == nil {
+			t.Errorf("Parse(%q, <fs.FileMode>) = nil; want error", in)
+		}
+	}
+}
+
+func TestFileModeIdentity(t *testing.T) {
+	testIdentity(t, fs.FileMode(0644))
+	testIdentity(t, fs.ModeSetuid|fs.ModeSticky|0700)
+	testIdentity(t, fs.ModeDir|fs.ModeSetgid|0755)
+	testIdentity(t, fs.ModeNamedPipe|fs.ModeIrregular|0600)
+}
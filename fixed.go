@@ -0,0 +1,137 @@
+package strconvert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode determines how decimals beyond the scale of a fixed-point
+// number are handled. See WithFixedPoint.
+type RoundingMode int
+
+const (
+	// RoundExact rejects inputs with more non-zero decimals than the scale.
+	RoundExact RoundingMode = iota
+	// RoundDown truncates excess decimals, rounding towards zero.
+	RoundDown
+	// RoundHalfUp rounds to the nearest number, rounding halfway cases away
+	// from zero.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest number, rounding halfway cases to
+	// the nearest even number.
+	RoundHalfEven
+)
+
+// WithFixedPoint makes [Parse] and [Stringify] treat values of the integer
+// type T as fixed-point decimal numbers with scale decimals. For example,
+// with a scale of 2, "12.34" is parsed exactly into 1234, and 1234 is
+// stringified as "12.34".
+//
+// Inputs with more decimals than scale are handled as set using the
+// WithRounding option, and are rejected by default. Parse errors if the
+// number does not fit in T. The scale must be between 0 and 18.
+func WithFixedPoint[T ~int64](scale int) func(*Options) {
+	return func(o *Options) {
+		if scale < 0 || scale > 18 {
+			o.savedErr = errors.Join(o.savedErr, fmt.Errorf("invalid fixed-point scale %d", scale))
+			return
+		}
+		if o.fixed == nil {
+			o.fixed = make(map[reflect.Type]int)
+		}
+		o.fixed[reflect.TypeOf(T(0))] = scale
+	}
+}
+
+// WithRounding sets how [Parse] handles decimals beyond the scale of
+// fixed-point numbers. The default is RoundExact.
+func WithRounding(mode RoundingMode) func(*Options) {
+	return func(o *Options) {
+		o.rounding = mode
+	}
+}
+
+func parseFixed(s string, scale int, mode RoundingMode) (int64, error) {
+	num := s
+	neg := strings.HasPrefix(num, "-")
+	if neg || strings.HasPrefix(num, "+") {
+		num = num[1:]
+	}
+	intPart, frac, _ := strings.Cut(num, ".")
+	if intPart == "" && frac == "" || !isDigits(intPart) || !isDigits(frac) {
+		return 0, fmt.Errorf("invalid fixed-point number %q", s)
+	}
+
+	var up bool
+	if len(frac) > scale {
+		kept, excess := frac[:scale], frac[scale:]
+		nonZero := strings.Trim(excess, "0") != ""
+		switch mode {
+		case RoundExact:
+			if nonZero {
+				return 0, fmt.Errorf("fixed-point number %q has more than %d decimals", s, scale)
+			}
+		case RoundHalfUp:
+			up = excess[0] >= '5'
+		case RoundHalfEven:
+			last := intPart + kept
+			odd := last != "" && (last[len(last)-1]-'0')%2 == 1
+			up = excess[0] > '5' || excess[0] == '5' && (strings.Trim(excess[1:], "0") != "" || odd)
+		}
+		frac = kept
+	}
+	digits := intPart + frac + strings.Repeat("0", scale-len(frac))
+	if neg {
+		digits = "-" + digits
+	}
+
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("fixed-point number %q out of range", s)
+	}
+	if up {
+		if neg {
+			if n == math.MinInt64 {
+				return 0, fmt.Errorf("fixed-point number %q out of range", s)
+			}
+			n--
+		} else {
+			if n == math.MaxInt64 {
+				return 0, fmt.Errorf("fixed-point number %q out of range", s)
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func formatFixed(n int64, scale int) string {
+	u := uint64(n)
+	if n < 0 {
+		u = -u
+	}
+	digits := strconv.FormatUint(u, 10)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	if scale > 0 {
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if n < 0 {
+		digits = "-" + digits
+	}
+	return digits
+}
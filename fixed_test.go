@@ -0,0 +1,60 @@
+package strconvert_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nahojer/strconvert"
+)
+
+type Cents int64
+
+type Percent int64
+
+func TestFixedPoint(t *testing.T) {
+	cents := strconvert.WithFixedPoint[Cents](2)
+	testParse(t, "12.34", Cents(1234), cents)
+	testParse(t, "12.3", Cents(1230), cents)
+	testParse(t, "12", Cents(1200), cents)
+	testParse(t, "-0.05", Cents(-5), cents)
+	testParse(t, ".5", Cents(50), cents)
+	testParse(t, "+1.", Cents(100), cents)
+	testParse(t, "1.2300", Cents(123), cents)
+	testParse(t, "0.1;0.2", []Cents{10, 20}, cents)
+	testParse(t, "12", Percent(12), strconvert.WithFixedPoint[Percent](0))
+	testParse(t, "92233720368547758.07", Cents(math.MaxInt64), cents)
+
+	testParse(t, "1.235", Cents(123), cents, strconvert.WithRounding(strconvert.RoundDown))
+	testParse(t, "-1.239", Cents(-123), cents, strconvert.WithRounding(strconvert.RoundDown))
+	testParse(t, "1.235", Cents(124), cents, strconvert.WithRounding(strconvert.RoundHalfUp))
+	testParse(t, "-1.235", Cents(-124), cents, strconvert.WithRounding(strconvert.RoundHalfUp))
+	testParse(t, "1.234", Cents(123), cents, strconvert.WithRounding(strconvert.RoundHalfUp))
+	testParse(t, "1.225", Cents(122), cents, strconvert.WithRounding(strconvert.RoundHalfEven))
+	testParse(t, "1.235", Cents(124), cents, strconvert.WithRounding(strconvert.RoundHalfEven))
+	testParse(t, "1.2251", Cents(123), cents, strconvert.WithRounding(strconvert.RoundHalfEven))
+	testParse(t, "0.005", Cents(0), cents, strconvert.WithRounding(strconvert.RoundHalfEven))
+
+	testStringify(t, Cents(1234), "12.34", cents)
+	testStringify(t, Cents(5), "0.05", cents)
+	testStringify(t, Cents(-5), "-0.05", cents)
+	testStringify(t, Cents(0), "0.00", cents)
+	testStringify(t, Cents(math.MinInt64), "-92233720368547758.08", cents)
+	testStringify(t, Percent(7), "7", strconvert.WithFixedPoint[Percent](0))
+	testStringify(t, int64(1234), "1234", cents)
+
+	for _, in := range []string{"1.234", "", ".", "1,5", "1e3", "--1", "92233720368547758.08"} {
+		var c Cents
+		if err := strconvert.Parse(in, reflect.ValueOf(&c).Elem(), cents); err == nil {
+			t.Errorf("Parse(%q, <Cents>, WithFixedPoint(2)) = nil; want error", in)
+		}
+	}
+	var c Cents
+	err := strconvert.Parse("92233720368547758.075", reflect.ValueOf(&c).Elem(), cents, strconvert.WithRounding(strconvert.RoundHalfUp))
+	if err == nil {
+		t.Errorf("Parse(<overflowing>, <Cents>, RoundHalfUp) = nil; want error")
+	}
+	if err := strconvert.Parse("1", reflect.ValueOf(&c).Elem(), strconvert.WithFixedPoint[Cents](19)); err == nil {
+		t.Errorf("Parse(\"1\", <Cents>, WithFixedPoint(19)) = nil; want error")
+	}
+}
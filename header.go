@@ -0,0 +1,236 @@
+package strconvert
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// DecodeHeader parses the values of h into the exported fields of the struct
+// pointed to by dst. If dst is not a non-nil pointer to a struct,
+// DecodeHeader returns an ErrInvalidParseArgument.
+//
+// A field is matched against the header named by its "header" struct tag, or
+// against the field name if the tag is absent. Header names are compared in
+// their canonical form (see [http.CanonicalHeaderKey]). Fields tagged with
+// `header:"-"` are left untouched. Fields whose header is missing from h are
+// set to their default value (see [ApplyDefaults]), if any, or else left
+// untouched.
+//
+// Slice fields, except for []byte, follow the list semantics of RFC 9110: all
+// lines of the header are combined and split on commas, and every non-empty
+// element is parsed separately. Elements may be quoted strings. Any other
+// field is parsed from the first value of the header.
+//
+// Values are parsed in the same way as [Parse] would, using the same options.
+// The decoded struct is then validated as described by [Validate].
+func DecodeHeader(h http.Header, dst any, optFns ...func(*Options)) error {
+	opts, err := optionsFor(optFns, headerTypes(reflect.TypeOf(dst))...)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidParseArgument
+	}
+	v = v.Elem()
+
+	for _, f := range structFields(v.Type()) {
+		key, ok := headerKey(f)
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		vals := h.Values(key)
+		if len(vals) == 0 {
+			if err := setDefault(f, fv, &opts); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := decodeHeaderField(vals, fv, fieldOptions(f, &opts)); err != nil {
+			return fmt.Errorf("error decoding header %s: %w", key, err)
+		}
+	}
+	return validate(v, opts.rules, &opts)
+}
+
+// EncodeHeader stringifies the exported fields of the struct (or pointer to
+// struct) src and sets them in h. It is the inverse operation of
+// [DecodeHeader] and matches fields to header names in the same way.
+//
+// Slice fields, except for []byte, are written as a single comma-separated
+// list. Elements containing commas, double quotes or surrounding whitespace
+// are written as quoted strings. Nil pointers, slices and maps are not
+// written.
+func EncodeHeader(h http.Header, src any, optFns ...func(*Options)) error {
+	opts, err := optionsFor(optFns, headerTypes(reflect.TypeOf(src))...)
+	if err != nil {
+		return err
+	}
+	v := reflect.Indirect(reflect.ValueOf(src))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported header source type %T", src)
+	}
+
+	for _, f := range structFields(v.Type()) {
+		key, ok := headerKey(f)
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		switch fv.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			if fv.IsNil() {
+				continue
+			}
+		}
+		s, err := encodeHeaderField(fv, fieldOptions(f, &opts))
+		if err != nil {
+			return fmt.Errorf("error encoding header %s: %w", key, err)
+		}
+		h.Set(key, s)
+	}
+	return nil
+}
+
+func headerKey(f reflect.StructField) (string, bool) {
+	name := f.Tag.Get("header")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return http.CanonicalHeaderKey(name), true
+}
+
+// headerTypes returns the types of the values that the headers of the
+// struct type typ, or pointer to it, are converted from and to: the types of
+// its fields, or the element types of list fields, which are split on commas
+// rather than on the element separator.
+func headerTypes(typ reflect.Type) []reflect.Type {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+	var typs []reflect.Type
+	for _, f := range structFields(typ) {
+		if _, ok := headerKey(f); !ok {
+			continue
+		}
+		ft := f.Type
+		if isHeaderList(ft) {
+			ft = ft.Elem()
+		}
+		typs = append(typs, ft)
+	}
+	return typs
+}
+
+func isHeaderList(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8
+}
+
+func decodeHeaderField(vals []string, v reflect.Value, opts *Options) error {
+	if !isHeaderList(v.Type()) {
+		return parse(vals[0], v, opts)
+	}
+
+	var elems []string
+	for _, val := range vals {
+		elems = append(elems, splitHeaderList(val)...)
+	}
+	sl := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+	for i, elem := range elems {
+		if err := parse(elem, sl.Index(i), opts); err != nil {
+			return err
+		}
+	}
+	v.Set(sl)
+	return nil
+}
+
+func encodeHeaderField(v reflect.Value, opts *Options) (string, error) {
+	if !isHeaderList(v.Type()) {
+		return stringify(v, opts)
+	}
+
+	elems := make([]string, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		s, err := stringify(v.Index(i), opts)
+		if err != nil {
+			return "", fmt.Errorf("error stringifying slice item of index %d: %w", i, err)
+		}
+		if s == "" || strings.ContainsAny(s, ",\"") || strings.TrimSpace(s) != s {
+			s = quoteHeaderElem(s)
+		}
+		elems[i] = s
+	}
+	return strings.Join(elems, ", "), nil
+}
+
+// splitHeaderList splits a comma-separated header value into its non-empty
+// elements, trimming optional whitespace and unquoting quoted strings.
+func splitHeaderList(s string) []string {
+	var (
+		elems   []string
+		b       strings.Builder
+		quoted  bool // element was (partly) quoted, so keep it even if empty
+		inQuote bool
+		escaped bool
+	)
+	flush := func() {
+		elem := b.String()
+		if !quoted {
+			elem = strings.TrimSpace(elem)
+		}
+		if elem != "" || quoted {
+			elems = append(elems, elem)
+		}
+		b.Reset()
+		quoted = false
+	}
+	for _, r := range s {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case inQuote && r == '\\':
+			escaped = true
+		case r == '"':
+			if !inQuote && !quoted {
+				// Drop whitespace preceding the opening quote.
+				trimmed := strings.TrimSpace(b.String())
+				b.Reset()
+				b.WriteString(trimmed)
+			}
+			inQuote = !inQuote
+			quoted = true
+		case !inQuote && r == ',':
+			flush()
+		case !inQuote && quoted && (r == ' ' || r == '\t'):
+			// Whitespace following a closing quote.
+		default:
+			b.WriteRune(r)
+		}
+	}
+	flush()
+	return elems
+}
+
+func quoteHeaderElem(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package strconvert_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nahojer/strconvert"
+)
+
+type GatewayHeaders struct {
+	Timeout     time.Duration `header:"X-Timeout"`
+	RetryBudget int           `header:"x-retry-budget"`
+	Tags        []string      `header:"X-Tags"`
+	Weights     []float64     `header:"X-Weights"`
+	Token       *string       `header:"Authorization"`
+	Internal    string        `header:"-"`
+	Accept      string
+}
+
+func TestDecodeHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Timeout", "1m30s")
+	h.Set("X-Retry-Budget", "3")
+	h.Add("X-Tags", `a, "b, c" ,, d`)
+	h.Add("X-Tags", `"e \"quoted\""`)
+	h.Set("X-Weights", "0.5,1.5")
+	h.Set("Authorization", "secret")
+	h.Set("Internal", "ignored")
+	h.Set("Accept", "text/plain")
+
+	var got GatewayHeaders
+	if err := strconvert.DecodeHeader(h, &got); err != nil {
+		t.Fatalf("DecodeHeader(%v) = %q; want nil", h, err)
+	}
+	token := "secret"
+	want := GatewayHeaders{
+		Timeout:     time.Minute + 30*time.Second,
+		RetryBudget: 3,
+		Tags:        []string{"a", "b, c", "d", `e "quoted"`},
+		Weights:     []float64{0.5, 1.5},
+		Token:       &token,
+		Accept:      "text/plain",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DecodeHeader(%v) mismatch (-want +got):\n%s", h, diff)
+	}
+
+	if err := strconvert.DecodeHeader(h, got); !errors.Is(err, strconvert.ErrInvalidParseArgument) {
+		t.Errorf("DecodeHeader(<struct>) = %v; want ErrInvalidParseArgument", err)
+	}
+
+	h.Set("X-Retry-Budget", "many")
+	if err := strconvert.DecodeHeader(h, &got); err == nil {
+		t.Errorf("DecodeHeader(%v) = nil; want error", h)
+	}
+}
+
+func TestHeaderIdentity(t *testing.T) {
+	orig := GatewayHeaders{
+		Timeout:     2 * time.Second,
+		RetryBudget: 10,
+		Tags:        []string{"plain", "with, comma", ` padded `, `"quoted"`, ""},
+		Weights:     []float64{1, 2.5},
+		Internal:    "not encoded",
+		Accept:      "*/*",
+	}
+	h := http.Header{}
+	if err := strconvert.EncodeHeader(h, orig); err != nil {
+		t.Fatalf("EncodeHeader(%v) = %q; want nil", orig, err)
+	}
+	if got := h.Get("Internal"); got != "" {
+		t.Errorf("EncodeHeader wrote ignored field as %q", got)
+	}
+	if _, ok := h["Authorization"]; ok {
+		t.Errorf("EncodeHeader wrote nil pointer field")
+	}
+
+	var parsed GatewayHeaders
+	if err := strconvert.DecodeHeader(h, &parsed); err != nil {
+		t.Fatalf("DecodeHeader(%v) = %q; want nil", h, err)
+	}
+	orig.Internal = ""
+	if diff := cmp.Diff(orig, parsed); diff != "" {
+		t.Errorf("identity mismatch (-orig +parsed):\n%s", diff)
+	}
+}
@@ -39,6 +39,14 @@ func (b *BinaryStruct) UnmarshalText(data []byte) error {
 	return nil
 }
 
+// Backend is a tuple struct, encoded as "host;port;weight".
+type Backend struct {
+	_      struct{} `strconvert:",tuple"`
+	Host   string
+	Port   uint16
+	Weight float64
+}
+
 func testIdentity[T any](t *testing.T, orig T) {
 	s, err := strconvert.Stringify(reflect.ValueOf(&orig))
 	if err != nil {
@@ -125,6 +133,74 @@ func Fuzz17_Bytes(f *testing.F) {
 	f.Fuzz(func(t *testing.T, orig []byte) { testIdentity(t, orig) })
 }
 
+func Fuzz18_FixedPoint(f *testing.F) {
+	f.Add(int64(1234), uint8(2))
+	f.Fuzz(func(t *testing.T, n int64, scale uint8) {
+		opt := strconvert.WithFixedPoint[Cents](int(scale % 19))
+		s, err := strconvert.Stringify(reflect.ValueOf(Cents(n)), opt)
+		if err != nil {
+			t.Fatalf("Stringify(%d) = \"\", %q", n, err)
+		}
+		var parsed Cents
+		if err := strconvert.Parse(s, reflect.ValueOf(&parsed).Elem(), opt); err != nil {
+			t.Fatalf("Parse(%q) = %q", s, err)
+		}
+		if parsed != Cents(n) {
+			t.Errorf("parsed %d, orig %d", parsed, n)
+		}
+	})
+}
+
+func Fuzz19_CSV(f *testing.F) {
+	f.Add("a, b", `d "quoted"`, "")
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		orig := []string{a, b, c}
+		csv := strconvert.WithCSVElements()
+		s, err := strconvert.Stringify(reflect.ValueOf(orig), csv)
+		if err != nil {
+			t.Fatalf("Stringify(%q) = \"\", %q", orig, err)
+		}
+		var parsed []string
+		if err := strconvert.Parse(s, reflect.ValueOf(&parsed).Elem(), csv); err != nil {
+			t.Fatalf("Parse(%q) = %q", s, err)
+		}
+		if !cmp.Equal(parsed, orig) {
+			t.Errorf("parsed %q, orig %q", parsed, orig)
+		}
+
+		origMap := map[string]string{a: b, c: a}
+		if s, err = strconvert.Stringify(reflect.ValueOf(origMap), csv); err != nil {
+			t.Fatalf("Stringify(%q) = \"\", %q", origMap, err)
+		}
+		var parsedMap map[string]string
+		if err := strconvert.Parse(s, reflect.ValueOf(&parsedMap).Elem(), csv); err != nil {
+			t.Fatalf("Parse(%q) = %q", s, err)
+		}
+		if !cmp.Equal(parsedMap, origMap) {
+			t.Errorf("parsed %q, orig %q", parsedMap, origMap)
+		}
+	})
+}
+
+func Fuzz20_ShellWords(f *testing.F) {
+	f.Add("a b", `c'd`, "")
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		orig := []string{a, b, c}
+		sh := strconvert.WithShellWords()
+		s, err := strconvert.Stringify(reflect.ValueOf(orig), sh)
+		if err != nil {
+			t.Fatalf("Stringify(%q) = \"\", %q", orig, err)
+		}
+		var parsed []string
+		if err := strconvert.Parse(s, reflect.ValueOf(&parsed).Elem(), sh); err != nil {
+			t.Fatalf("Parse(%q) = %q", s, err)
+		}
+		if !cmp.Equal(parsed, orig) {
+			t.Errorf("parsed %q, orig %q", parsed, orig)
+		}
+	})
+}
+
 func TestComplexIdentity(t *testing.T) {
 	testIdentity(t, complex64(49+23i))
 	testIdentity(t, complex128(-1000-50i))
@@ -149,6 +225,9 @@ func TestSliceIdentity(t *testing.T) {
 func TestArrayIdentity(t *testing.T) {
 	testIdentity(t, [10]int{1, 2, 3, 4, 5, 6, 7, 8, 9})
 	testIdentity(t, [30]float64{})
+	testIdentity(t, [4]byte{0xde, 0xad, 0xbe, 0xef})
+	testIdentity(t, [16]byte{0xf8, 0x1d, 0x4f, 0xae})
+	testIdentity(t, [][32]byte{{1}, {2}})
 }
 
 func TestTextStructIdentity(t *testing.T) {
@@ -158,3 +237,8 @@ func TestTextStructIdentity(t *testing.T) {
 func TestBinaryStructIdentity(t *testing.T) {
 	testIdentity(t, BinaryStruct{Value: "binary unmarshaler and marshaler"})
 }
+
+func TestTupleIdentity(t *testing.T) {
+	testIdentity(t, Backend{Host: "10.0.0.1", Port: 8080, Weight: 0.5})
+	testIdentity(t, Backend{})
+}
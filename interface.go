@@ -5,6 +5,11 @@ import (
 	"reflect"
 )
 
+var (
+	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
 func textUnmarshaler(field reflect.Value) (t encoding.TextUnmarshaler) {
 	interfaceFrom(field, func(v any, ok *bool) {
 		t, *ok = v.(encoding.TextUnmarshaler)
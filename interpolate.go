@@ -0,0 +1,132 @@
+package strconvert
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WithInterpolation makes [Parse] expand variables in the input before
+// converting it. Variables are looked up using lookup, or [os.LookupEnv] if
+// lookup is nil. The following forms are expanded:
+//
+//   - ${NAME}: the value of NAME, or the empty string if NAME is not set
+//   - ${NAME:-default}: the value of NAME, or default if NAME is not set or
+//     empty
+//   - ${NAME:?message}: the value of NAME, or an error with message if NAME is
+//     not set or empty
+//
+// Defaults and messages may contain variables themselves. "$$" is expanded
+// to a literal "$", and a "$" that is not followed by "{" or "$" is kept as
+// is.
+//
+// Variables are expanded in the input as a whole, before it is split into
+// the elements of slices, arrays, maps and tuples, as a separate pre-pass
+// would. A variable may thus expand to several elements, as in "${HOSTS}"
+// with HOSTS set to "a;b", and defaults may contain separators, as in
+// "host:${HOST:-localhost}". Expanded values are not expanded again.
+// References are resolved afterwards, per element (see WithResolver).
+//
+// Use [LookupPath] to look up variables in other fields of the same struct.
+func WithInterpolation(lookup func(name string) (string, bool)) func(*Options) {
+	return func(o *Options) {
+		if lookup == nil {
+			lookup = os.LookupEnv
+		}
+		o.lookup = lookup
+	}
+}
+
+// LookupPath returns a lookup function for use with the WithInterpolation
+// option that looks up variables as paths into root (see [SetPath]), so that
+// "${db.host}" expands to the stringified value of the field root.DB.Host.
+// Variables are not set if their path does not exist or runs into a nil
+// pointer.
+func LookupPath(root any, optFns ...func(*Options)) func(name string) (string, bool) {
+	return func(name string) (string, bool) {
+		s, err := GetPath(root, name, optFns...)
+		return s, err == nil
+	}
+}
+
+func interpolate(s string, lookup func(string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := closingBrace(s, i+1)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable in %q", s)
+			}
+			val, err := expandVariable(s[i+2:end], lookup)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(val)
+			i = end
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// closingBrace returns the index of the brace closing the one at index open
+// of s, or -1 if there is none.
+func closingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func expandVariable(expr string, lookup func(string) (string, bool)) (string, error) {
+	name, op, word := expr, "", ""
+	if i := strings.Index(expr, ":-"); i >= 0 {
+		name, op, word = expr[:i], ":-", expr[i+2:]
+	}
+	if i := strings.Index(name, ":?"); i >= 0 {
+		name, op, word = expr[:i], ":?", expr[i+2:]
+	}
+	if name == "" {
+		return "", fmt.Errorf("invalid variable ${%s}", expr)
+	}
+
+	if val, ok := lookup(name); ok && (val != "" || op == "") {
+		return val, nil
+	}
+	switch op {
+	case ":-":
+		return interpolate(word, lookup)
+	case ":?":
+		msg, err := interpolate(word, lookup)
+		if err != nil {
+			return "", err
+		}
+		if msg == "" {
+			msg = "not set"
+		}
+		return "", fmt.Errorf("variable %s: %s", name, msg)
+	}
+	return "", nil
+}
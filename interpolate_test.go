@@ -0,0 +1,73 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestInterpolation(t *testing.T) {
+	t.Setenv("STRCONVERT_HOST", "example.com")
+	t.Setenv("STRCONVERT_EMPTY", "")
+	t.Setenv("STRCONVERT_HOSTS", "a;b")
+	t.Setenv("STRCONVERT_DOLLAR", "$$")
+
+	env := strconvert.WithInterpolation(nil)
+	testParse(t, "https://${STRCONVERT_HOST}/", "https://example.com/", env)
+	testParse(t, "${STRCONVERT_UNSET}", "", env)
+	testParse(t, "${STRCONVERT_EMPTY:-fallback}", "fallback", env)
+	testParse(t, "${STRCONVERT_UNSET:-${STRCONVERT_HOST}}", "example.com", env)
+	testParse(t, "${STRCONVERT_RETRIES:-3}", 3, env)
+	testParse(t, "$$HOME;$x;${STRCONVERT_HOST}", []string{"$HOME", "$x", "example.com"}, env)
+	testParse(t, "host:${STRCONVERT_HOST}", map[string]string{"host": "example.com"}, env)
+	testParse(t, "host:${STRCONVERT_UNSET:-localhost:8080}", map[string]string{"host": "localhost:8080"}, env)
+	testParse(t, "${STRCONVERT_HOSTS}", []string{"a", "b"}, env)
+	testParse(t, "${STRCONVERT_HOSTS};c", []string{"a", "b", "c"}, env)
+	testParse(t, "${STRCONVERT_DOLLAR}", "$$", env)
+	testParse(t, "${STRCONVERT_HOST}", "${STRCONVERT_HOST}")
+
+	type Endpoint struct {
+		_    struct{} `strconvert:",tuple"`
+		Host string
+		Port int `default:"${STRCONVERT_PORT:-80}"`
+	}
+	testParse(t, "${STRCONVERT_HOST}", Endpoint{Host: "example.com", Port: 80}, env)
+
+	vars := map[string]string{"TIMEOUT": "file:/etc/timeout"}
+	lookup := func(name string) (string, bool) { v, ok := vars[name]; return v, ok }
+	testParse(t, "${TIMEOUT}", 2*time.Second,
+		strconvert.WithInterpolation(lookup),
+		strconvert.WithResolver("file", func(string) (string, error) { return "2s", nil }))
+
+	var s string
+	for in, want := range map[string]string{
+		"${STRCONVERT_UNSET:?must be set}": "STRCONVERT_UNSET: must be set",
+		"${STRCONVERT_EMPTY:?}":            "STRCONVERT_EMPTY: not set",
+		"${STRCONVERT_HOST":                "unterminated",
+		"${:-x}":                           "invalid variable",
+	} {
+		err := strconvert.Parse(in, reflect.ValueOf(&s).Elem(), env)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) = %v; want error containing %q", in, err, want)
+		}
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	type Config struct {
+		Host string
+		Port int
+		URL  string
+	}
+	cfg := Config{Host: "example.com", Port: 8080}
+	opt := strconvert.WithInterpolation(strconvert.LookupPath(&cfg))
+	if err := strconvert.SetPath(&cfg, "url", "http://${host}:${port}/${missing:-}", opt); err != nil {
+		t.Fatalf("SetPath(url) = %q; want nil", err)
+	}
+	if want := "http://example.com:8080/"; cfg.URL != want {
+		t.Errorf("cfg.URL = %q; want %q", cfg.URL, want)
+	}
+}
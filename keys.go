@@ -0,0 +1,76 @@
+package strconvert
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// WithKeyComparator registers a comparison function for map keys of type K,
+// used by [Stringify] to order the key, value pairs of maps. fn returns a
+// negative number if a is ordered before b, a positive number if a is
+// ordered after b, and zero otherwise, as the functions of package cmp do.
+func WithKeyComparator[K any](fn func(a, b K) int) func(*Options) {
+	return func(o *Options) {
+		if o.keyCmps == nil {
+			o.keyCmps = make(map[reflect.Type]reflect.Value)
+		}
+		o.keyCmps[reflect.TypeOf((*K)(nil)).Elem()] = reflect.ValueOf(fn)
+	}
+}
+
+// mapEntry is a key, value pair of a map being stringified.
+type mapEntry struct {
+	key reflect.Value
+	sk  string // the stringified key
+	s   string // the stringified pair
+}
+
+// sortEntries sorts entries by key, using the comparator registered for the
+// key type, if any, or else the natural order of the keys. Keys without a
+// natural order, and keys that compare as equal, are ordered by their
+// stringified form.
+func sortEntries(entries []mapEntry, opts *Options) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if c := compareKeys(a.key, b.key, opts); c != 0 {
+			return c < 0
+		}
+		if a.sk != b.sk {
+			return a.sk < b.sk
+		}
+		return a.s < b.s
+	})
+}
+
+// compareKeys compares the map keys a and b of the same type, and returns
+// zero if they are equal or have no natural order.
+func compareKeys(a, b reflect.Value, opts *Options) int {
+	if fn, ok := opts.keyCmps[a.Type()]; ok {
+		return int(fn.Call([]reflect.Value{a, b})[0].Int())
+	}
+	if a.Type() == timeType && a.CanInterface() {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compare(a.Float(), b.Float())
+	case reflect.String:
+		return compare(a.String(), b.String())
+	case reflect.Bool:
+		switch {
+		case !a.Bool() && b.Bool():
+			return -1
+		case a.Bool() && !b.Bool():
+			return 1
+		}
+	}
+	return 0
+}
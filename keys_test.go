@@ -0,0 +1,38 @@
+package strconvert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nahojer/strconvert"
+)
+
+type Level int
+
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte([]string{"debug", "info", "warn"}[l]), nil
+}
+
+func TestMapKeyOrder(t *testing.T) {
+	testStringify(t, map[int]string{1: "a", 10: "b", 2: "c", -3: "d"}, "-3:d;1:a;2:c;10:b")
+	testStringify(t, map[uint16]bool{100: true, 9: false}, "9:false;100:true")
+	testStringify(t, map[float64]int{1.5: 1, -0.5: 2, 10: 3}, "-0.5:2;1.5:1;10:3")
+	testStringify(t, map[string]int{"a-b": 1, "a": 2, "B": 3}, "B:3;a:2;a-b:1")
+	testStringify(t, map[bool]int{true: 1, false: 0}, "false:0;true:1")
+	testStringify(t, map[Level]int{2: 1, 0: 2, 1: 3}, "debug:2;info:3;warn:1")
+	testStringify(t, map[time.Duration]int{time.Hour: 1, time.Minute: 2}, "1m0s:2;1h0m0s:1")
+
+	// Times are ordered by value, not by their day-first formatting.
+	const layout = "02.01.2006"
+	formatDate := strconvert.WithStringifier(func(t time.Time) (string, error) { return t.Format(layout), nil })
+	parseDate := strconvert.WithParser(func(s string) (time.Time, error) { return time.Parse(layout, s) })
+	jan2, feb1 := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	testStringify(t, map[time.Time]int{feb1: 1, jan2: 2}, "02.01.2024:2;01.02.2024:1", formatDate)
+	testParse(t, "02.01.2024:2;01.02.2024:1", map[time.Time]int{feb1: 1, jan2: 2}, parseDate)
+
+	reverse := strconvert.WithKeyComparator(func(a, b int) int { return b - a })
+	testStringify(t, map[int]string{1: "a", 10: "b", 2: "c"}, "10:b;2:c;1:a", reverse)
+	testStringify(t, map[int8]string{1: "a", 10: "b", 2: "c"}, "1:a;2:c;10:b", reverse)
+	byLen := strconvert.WithKeyComparator(func(a, b string) int { return len(a) - len(b) })
+	testStringify(t, map[string]int{"ccc": 1, "a": 2, "bb": 3, "b": 4}, "a:2;b:4;bb:3;ccc:1", byLen)
+}
@@ -0,0 +1,73 @@
+package strconvert
+
+import "reflect"
+
+// DuplicateKeys determines how [Parse] handles map keys that are already
+// present, either earlier in the input or, when using the WithMergeMaps
+// option, in the map being parsed into. See WithDuplicateKeys.
+type DuplicateKeys int
+
+const (
+	// DuplicateOverwrite replaces the value of the existing key.
+	DuplicateOverwrite DuplicateKeys = iota
+	// DuplicateKeep keeps the value of the existing key.
+	DuplicateKeep
+	// DuplicateError makes Parse return an error.
+	DuplicateError
+)
+
+// WithMergeMaps makes [Parse] add the parsed key, value pairs to the map it
+// parses into, if it is non-nil, rather than replacing it, so that layered
+// configuration, such as defaults followed by a file and then flags, can be
+// parsed into the same value. The map is copied, so that other references to
+// it are left unchanged. [OrderedMap] values are merged in the same way.
+//
+// Keys already present are handled as set using the WithDuplicateKeys
+// option, and are overwritten by default.
+func WithMergeMaps() func(*Options) {
+	return func(o *Options) {
+		o.mergeMaps = true
+	}
+}
+
+// WithAppendSlices makes [Parse] append the parsed elements to the slice it
+// parses into, rather than replacing it. Byte slices, which are parsed as a
+// whole, are replaced as usual.
+func WithAppendSlices() func(*Options) {
+	return func(o *Options) {
+		o.appendSlices = true
+	}
+}
+
+// WithDuplicateKeys sets how [Parse] handles map keys that are already
+// present. The default is DuplicateOverwrite.
+func WithDuplicateKeys(mode DuplicateKeys) func(*Options) {
+	return func(o *Options) {
+		o.dupKeys = mode
+	}
+}
+
+// existingMap returns a copy of the map v if the WithMergeMaps option is
+// used and v is non-nil, and a new, empty map otherwise.
+func existingMap(v reflect.Value, opts *Options) reflect.Value {
+	m := reflect.MakeMap(v.Type())
+	if !opts.mergeMaps || v.IsNil() {
+		return m
+	}
+	iter := v.MapRange()
+	for iter.Next() {
+		m.SetMapIndex(iter.Key(), iter.Value())
+	}
+	return m
+}
+
+// appendElems appends the slice elems to the slice v if the WithAppendSlices
+// option is used, and returns elems otherwise.
+func appendElems(v, elems reflect.Value, opts *Options) reflect.Value {
+	if !opts.appendSlices || v.IsNil() {
+		return elems
+	}
+	// Limit the capacity, so that the elements are copied rather than
+	// written to the array of v.
+	return reflect.AppendSlice(v.Slice3(0, v.Len(), v.Len()), elems)
+}
@@ -0,0 +1,91 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nahojer/strconvert"
+)
+
+func TestMergeMaps(t *testing.T) {
+	merge := strconvert.WithMergeMaps()
+
+	for _, tc := range []struct {
+		in   string
+		mode strconvert.DuplicateKeys
+		want map[string]int
+	}{
+		{"b:20;c:3", strconvert.DuplicateOverwrite, map[string]int{"a": 1, "b": 20, "c": 3}},
+		{"b:20;c:3", strconvert.DuplicateKeep, map[string]int{"a": 1, "b": 2, "c": 3}},
+		{"c:3;c:30", strconvert.DuplicateKeep, map[string]int{"a": 1, "b": 2, "c": 3}},
+		{"c:3;c:30", strconvert.DuplicateOverwrite, map[string]int{"a": 1, "b": 2, "c": 30}},
+		{"", strconvert.DuplicateError, map[string]int{"a": 1, "b": 2}},
+	} {
+		orig := map[string]int{"a": 1, "b": 2}
+		m := orig
+		err := strconvert.Parse(tc.in, reflect.ValueOf(&m).Elem(), merge, strconvert.WithDuplicateKeys(tc.mode))
+		if err != nil {
+			t.Fatalf("Parse(%q, <map>, WithMergeMaps(), %d) = %q", tc.in, tc.mode, err)
+		}
+		if !cmp.Equal(m, tc.want) {
+			t.Errorf("Parse(%q, <map>, WithMergeMaps(), %d) = %v; want %v", tc.in, tc.mode, m, tc.want)
+		}
+		if len(orig) != 2 {
+			t.Errorf("Parse(%q, <map>, WithMergeMaps(), %d) modified the original map: %v", tc.in, tc.mode, orig)
+		}
+	}
+
+	testParse(t, "a:1;b:2", map[string]int{"a": 1, "b": 2}, merge)
+	testParse(t, "a:1;a:2", map[string]int{"a": 1}, strconvert.WithDuplicateKeys(strconvert.DuplicateKeep))
+
+	m := map[string]int{"a": 1}
+	if err := strconvert.Parse("[]", reflect.ValueOf(&m).Elem(), merge, strconvert.WithEmptyLiteral("[]")); err != nil || len(m) != 1 {
+		t.Errorf("Parse(\"[]\", <map>, WithMergeMaps(), WithEmptyLiteral(\"[]\")) = %v, %q; want map[a:1], nil", m, err)
+	}
+	for _, in := range []string{"a:2", "b:1;b:2"} {
+		m := map[string]int{"a": 1}
+		err := strconvert.Parse(in, reflect.ValueOf(&m).Elem(), merge, strconvert.WithDuplicateKeys(strconvert.DuplicateError))
+		if err == nil {
+			t.Errorf("Parse(%q, <map>, WithMergeMaps(), DuplicateError) = nil; want error", in)
+		}
+		if !cmp.Equal(m, map[string]int{"a": 1}) {
+			t.Errorf("Parse(%q, <map>, WithMergeMaps(), DuplicateError) modified the map: %v", in, m)
+		}
+	}
+}
+
+func TestAppendSlices(t *testing.T) {
+	app := strconvert.WithAppendSlices()
+
+	orig := make([]string, 1, 10)
+	orig[0] = "a"
+	s := orig
+	if err := strconvert.Parse("b;c", reflect.ValueOf(&s).Elem(), app); err != nil {
+		t.Fatalf("Parse(\"b;c\", <slice>, WithAppendSlices()) = %q", err)
+	}
+	if !cmp.Equal(s, []string{"a", "b", "c"}) {
+		t.Errorf("Parse(\"b;c\", <slice>, WithAppendSlices()) = %v; want [a b c]", s)
+	}
+	if orig[:2][1] != "" {
+		t.Errorf("Parse(\"b;c\", <slice>, WithAppendSlices()) wrote to the original array")
+	}
+
+	testParse(t, "1;2", []int{1, 2}, app)
+
+	b := []byte("ab")
+	if err := strconvert.Parse("cd", reflect.ValueOf(&b).Elem(), app); err != nil || string(b) != "cd" {
+		t.Errorf("Parse(\"cd\", <[]byte>, WithAppendSlices()) = %q, %v; want \"cd\", nil", b, err)
+	}
+
+	layers := []string{"x;y", "[]", "z"}
+	var got []string
+	for _, in := range layers {
+		if err := strconvert.Parse(in, reflect.ValueOf(&got).Elem(), app, strconvert.WithEmptyLiteral("[]")); err != nil {
+			t.Fatalf("Parse(%q, <slice>, WithAppendSlices()) = %q", in, err)
+		}
+	}
+	if !cmp.Equal(got, []string{"x", "y", "z"}) {
+		t.Errorf("Parse(%v, <slice>, WithAppendSlices()) = %v; want [x y z]", layers, got)
+	}
+}
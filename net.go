@@ -0,0 +1,148 @@
+package strconvert
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ipNetType        = reflect.TypeOf(net.IPNet{})
+	hardwareAddrType = reflect.TypeOf(net.HardwareAddr{})
+	tcpAddrType      = reflect.TypeOf(net.TCPAddr{})
+	udpAddrType      = reflect.TypeOf(net.UDPAddr{})
+)
+
+// HostPort is a host and port pair, such as a listen address, formatted as
+// "host:port". IPv6 hosts are enclosed in square brackets, as in
+// "[::1]:8080". The host may be empty.
+type HostPort struct {
+	Host string
+	Port uint16
+}
+
+// String returns hp formatted as "host:port".
+func (hp HostPort) String() string {
+	return net.JoinHostPort(hp.Host, strconv.FormatUint(uint64(hp.Port), 10))
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (hp HostPort) MarshalText() ([]byte, error) {
+	return []byte(hp.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler]. The port must be
+// numeric; service names are not resolved.
+func (hp *HostPort) UnmarshalText(text []byte) error {
+	host, port, err := splitHostPort(string(text))
+	if err != nil {
+		return err
+	}
+	hp.Host, hp.Port = host, port
+	return nil
+}
+
+func splitHostPort(s string) (string, uint16, error) {
+	host, portStr, err := net.SplitHostPort(s)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port %q in address %q", portStr, s)
+	}
+	return host, uint16(port), nil
+}
+
+// parseIPPort parses a host:port pair whose host is an IP address, possibly
+// with an IPv6 zone, or empty.
+func parseIPPort(s string) (net.IP, string, int, error) {
+	host, port, err := splitHostPort(s)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	if host == "" {
+		return nil, "", int(port), nil
+	}
+	host, zone, _ := strings.Cut(host, "%")
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, "", 0, fmt.Errorf("invalid IP address %q in address %q", host, s)
+	}
+	return ip, zone, int(port), nil
+}
+
+// parseNet parses s into v if v is of one of the supported types of package
+// net that do not implement [encoding.TextUnmarshaler], and reports whether
+// it did.
+func parseNet(s string, v reflect.Value) (bool, error) {
+	switch v.Type() {
+	case ipNetType:
+		if s == "" {
+			v.Set(reflect.Zero(ipNetType))
+			return true, nil
+		}
+		ip, ipNet, err := net.ParseCIDR(s)
+		if err != nil {
+			return true, err
+		}
+		// Keep the host address, as in net/netip.Prefix, rather than the
+		// network address.
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		v.Set(reflect.ValueOf(net.IPNet{IP: ip, Mask: ipNet.Mask}))
+
+	case hardwareAddrType:
+		if s == "" {
+			v.Set(reflect.Zero(hardwareAddrType))
+			return true, nil
+		}
+		mac, err := net.ParseMAC(s)
+		if err != nil {
+			return true, err
+		}
+		v.Set(reflect.ValueOf(mac))
+
+	case tcpAddrType:
+		ip, zone, port, err := parseIPPort(s)
+		if err != nil {
+			return true, err
+		}
+		v.Set(reflect.ValueOf(net.TCPAddr{IP: ip, Port: port, Zone: zone}))
+
+	case udpAddrType:
+		ip, zone, port, err := parseIPPort(s)
+		if err != nil {
+			return true, err
+		}
+		v.Set(reflect.ValueOf(net.UDPAddr{IP: ip, Port: port, Zone: zone}))
+
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// stringifyNet is the inverse operation of parseNet.
+func stringifyNet(v reflect.Value) (string, bool) {
+	switch v.Type() {
+	case ipNetType:
+		ipNet := v.Interface().(net.IPNet)
+		if len(ipNet.IP) == 0 {
+			return "", true
+		}
+		return ipNet.String(), true
+	case hardwareAddrType:
+		return v.Interface().(net.HardwareAddr).String(), true
+	case tcpAddrType:
+		addr := v.Interface().(net.TCPAddr)
+		return addr.String(), true
+	case udpAddrType:
+		addr := v.Interface().(net.UDPAddr)
+		return addr.String(), true
+	}
+	return "", false
+}
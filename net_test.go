@@ -0,0 +1,62 @@
+package strconvert_test
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestNet(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/8")
+	_, cidr6, _ := net.ParseCIDR("2001:db8::/32")
+	mac, _ := net.ParseMAC("00:00:5e:00:53:01")
+
+	testParse(t, "10.0.0.0/8", *cidr)
+	testParse(t, "10.1.2.3/8", net.IPNet{IP: net.IPv4(10, 1, 2, 3).To4(), Mask: cidr.Mask})
+	testParse(t, "2001:db8::1/32", net.IPNet{IP: net.ParseIP("2001:db8::1"), Mask: cidr6.Mask})
+	testParse(t, "10.0.0.0/8;2001:db8::/32", []net.IPNet{*cidr, *cidr6})
+	testParse(t, "00-00-5E-00-53-01", mac)
+	testParse(t, "[::1]:8080", net.TCPAddr{IP: net.ParseIP("::1"), Port: 8080})
+	testParse(t, "[fe80::1%eth0]:53", net.UDPAddr{IP: net.ParseIP("fe80::1"), Port: 53, Zone: "eth0"})
+	testParse(t, ":8080", &net.TCPAddr{Port: 8080})
+	testParse(t, "localhost:8080", strconvert.HostPort{Host: "localhost", Port: 8080})
+	testParse(t, "[::1]:0", strconvert.HostPort{Host: "::1"})
+
+	testStringify(t, *cidr6, "2001:db8::/32")
+	testStringify(t, net.IPNet{IP: net.IPv4(10, 1, 2, 3).To4(), Mask: cidr.Mask}, "10.1.2.3/8")
+	testStringify(t, net.IPNet{}, "")
+	testStringify(t, mac, "00:00:5e:00:53:01")
+	testStringify(t, &net.TCPAddr{IP: net.ParseIP("::1"), Port: 8080}, "[::1]:8080")
+	testStringify(t, net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 53}, "127.0.0.1:53")
+	testStringify(t, strconvert.HostPort{Host: "::1", Port: 443}, "[::1]:443")
+
+	for _, tc := range []struct {
+		in string
+		v  any
+	}{
+		{"10.0.0.0", new(net.IPNet)},
+		{"00:00:5e", new(net.HardwareAddr)},
+		{"localhost:8080", new(net.TCPAddr)},
+		{"127.0.0.1:http", new(net.TCPAddr)},
+		{"[::1]:65536", new(net.UDPAddr)},
+		{"example.com:https", new(strconvert.HostPort)},
+		{"example.com", new(strconvert.HostPort)},
+	} {
+		if err := strconvert.Parse(tc.in, reflect.ValueOf(tc.v).Elem()); err == nil {
+			t.Errorf("Parse(%q, <%T>) = nil; want error", tc.in, tc.v)
+		}
+	}
+}
+
+func TestNetIdentity(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("192.168.0.0/16")
+	testIdentity(t, *cidr)
+	testIdentity(t, net.IPNet{IP: net.IPv4(192, 168, 1, 2).To4(), Mask: cidr.Mask})
+	testIdentity(t, net.IPNet{})
+	testIdentity(t, net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x53, 0x01})
+	testIdentity(t, net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 443})
+	testIdentity(t, net.UDPAddr{Port: 53})
+	testIdentity(t, strconvert.HostPort{Host: "example.com", Port: 80})
+}
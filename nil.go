@@ -0,0 +1,87 @@
+package strconvert
+
+import "reflect"
+
+// WithNilLiteral makes [Stringify] write lit for nil pointers, slices and
+// maps, and [Parse] set pointers, slices and maps to nil when given lit, as
+// in
+//
+//	strconvert.WithNilLiteral("null")
+//
+// Without this option, nil pointers are stringified as the empty string,
+// which Parse reads as a pointer to the zero value, and nil slices and maps
+// are stringified in the same way as empty ones. lit should be a string that
+// does not occur as a value, since a pointer to, or slice of, strings equal
+// to lit is parsed as nil.
+func WithNilLiteral(lit string) func(*Options) {
+	return func(o *Options) {
+		o.nilLit = lit
+		o.nilLitSet = true
+	}
+}
+
+// WithEmptyLiteral makes [Stringify] write lit for empty slices and maps,
+// and [Parse] set slices and maps to empty, non-nil values when given lit, as
+// in
+//
+//	strconvert.WithEmptyLiteral("[]")
+//
+// Without this option, empty slices are stringified as the empty string,
+// which Parse reads as a slice with a single element parsed from the empty
+// string. Unless the WithNilLiteral option is used as well, nil slices and
+// maps are stringified as lit too.
+//
+// lit must differ from the literal set using WithNilLiteral.
+func WithEmptyLiteral(lit string) func(*Options) {
+	return func(o *Options) {
+		o.emptyLit = lit
+		o.emptyLitSet = true
+	}
+}
+
+// isNilable reports whether values of typ are nil when matching the literal
+// set using WithNilLiteral.
+func isNilable(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// isCollection reports whether values of typ are empty when matching the
+// literal set using WithEmptyLiteral.
+func isCollection(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map || isOrderedMap(typ)
+}
+
+// collectionLen returns the number of elements of the collection v.
+func collectionLen(v reflect.Value) int {
+	if v.Kind() == reflect.Struct {
+		keys, _ := asOrderedMap(v).entries()
+		return len(keys)
+	}
+	return v.Len()
+}
+
+// parseEmpty sets the slice or map v to an empty, non-nil value, unless v is
+// non-nil and the WithAppendSlices or WithMergeMaps option is used. Ordered
+// maps are emptied unless the WithMergeMaps option is used.
+func parseEmpty(v reflect.Value, opts *Options) {
+	switch v.Kind() {
+	case reflect.Slice:
+		if opts.appendSlices && !v.IsNil() && !isBytes(v.Type()) {
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+	case reflect.Map:
+		if opts.mergeMaps && !v.IsNil() {
+			return
+		}
+		v.Set(reflect.MakeMap(v.Type()))
+	default:
+		if !opts.mergeMaps {
+			v.Set(reflect.Zero(v.Type()))
+		}
+	}
+}
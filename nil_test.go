@@ -0,0 +1,70 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nahojer/strconvert"
+)
+
+type Optional struct {
+	_       struct{} `strconvert:",tuple"`
+	Timeout *int
+	Tags    []string
+	Labels  map[string]string
+}
+
+func TestNilAndEmptyLiterals(t *testing.T) {
+	null := strconvert.WithNilLiteral("null")
+	empty := strconvert.WithEmptyLiteral("[]")
+	zero := 0
+
+	testStringify(t, (*int)(nil), "null", null)
+	testStringify(t, &zero, "0", null)
+	testStringify(t, []string(nil), "null", null)
+	testStringify(t, []string{}, "", null)
+	testStringify(t, []string{}, "[]", null, empty)
+	testStringify(t, []string(nil), "[]", empty)
+	testStringify(t, []string{""}, "", null, empty)
+	testStringify(t, map[string]int{}, "[]", null, empty)
+	testStringify(t, []byte{}, "[]", empty)
+	testStringify(t, []*int{nil, &zero}, "null;0", null)
+	testStringify(t, Optional{Tags: []string{}}, "null;[];null", null, empty)
+
+	testParse(t, "null", (*int)(nil), null)
+	testParse(t, "0", &zero, null)
+	testParse(t, "null", []string(nil), null)
+	testParse(t, "null", map[string]int(nil), null)
+	testParse(t, "[]", []string{}, empty)
+	testParse(t, "[]", map[string]int{}, empty)
+	testParse(t, "[]", []byte{}, empty)
+	testParse(t, "", []string{""}, empty)
+	testParse(t, "null;0", []*int{nil, &zero}, null)
+	testParse(t, "", (*int)(nil), strconvert.WithNilLiteral(""))
+	testParse(t, "null;[];a:b", Optional{Tags: []string{}, Labels: map[string]string{"a": "b"}}, null, empty)
+
+	for _, orig := range []Optional{
+		{},
+		{Timeout: &zero, Tags: []string{}, Labels: map[string]string{}},
+		{Tags: []string{""}},
+		{Tags: []string{"a"}, Labels: map[string]string{"k": "v"}},
+	} {
+		s, err := strconvert.Stringify(reflect.ValueOf(orig), null, empty)
+		if err != nil {
+			t.Fatalf("Stringify(%v) = \"\", %q", orig, err)
+		}
+		var parsed Optional
+		if err := strconvert.Parse(s, reflect.ValueOf(&parsed).Elem(), null, empty); err != nil {
+			t.Fatalf("Parse(%q) = %q", s, err)
+		}
+		if !cmp.Equal(parsed, orig) {
+			t.Errorf("parsed %v, orig %v", parsed, orig)
+		}
+	}
+
+	var s []string
+	if err := strconvert.Parse("x", reflect.ValueOf(&s).Elem(), strconvert.WithNilLiteral("-"), strconvert.WithEmptyLiteral("-")); err == nil {
+		t.Errorf("Parse(<same nil and empty literal>) = nil; want error")
+	}
+}
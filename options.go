@@ -4,13 +4,40 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // Options for modifying and/or extending the behaviour of [Stringify] and
 // [Parse].
 type Options struct {
-	elemSep, keySep rune
+	elemSep, keySep string
+	trimSpace       bool
+	csv             bool
+	shellWords      bool
 	funcs           map[reflect.Type]reflect.Value
+	rules           []rule
+	resolvers       map[string]func(string) (string, error)
+	lookup          func(string) (string, bool)
+	expanded        bool // the variables of the input have been expanded
+	ranges          bool
+	quantities      bool
+	fixed           map[reflect.Type]int
+	rounding        RoundingMode
+	expressions     bool
+	redacted        map[reflect.Type]bool
+	redact          bool
+	showLast        int
+	bytesEnc        BytesEncoding
+	bytesEncSet     bool
+	nilLit          string
+	nilLitSet       bool
+	emptyLit        string
+	emptyLitSet     bool
+	mergeMaps       bool
+	appendSlices    bool
+	dupKeys         DuplicateKeys
+	keyCmps         map[reflect.Type]reflect.Value
+	secret          bool // the value being stringified is a secret field
 	savedErr        error
 }
 
@@ -57,28 +84,112 @@ func WithStringifier[T any](fn func(v T) (string, error)) func(*Options) {
 }
 
 // WithElementSeparator overrides the default element separator used for
-// parsing/stringifying slices, arrays and maps.
+// parsing/stringifying slices, arrays and maps. Use
+// WithElementSeparatorString for separators of more than one rune.
+//
+// [Parse], [Stringify] and the other functions taking options error if the
+// element and key separators contain one another, or if a separator may
+// occur in the input of the elements it separates, such as '-' for a slice
+// of integers, which may be negative, or '.' for a slice of floats. The
+// whole type of the value passed is checked, including all fields of
+// structs that are converted field by field, as by [SetPath].
 func WithElementSeparator(r rune) func(*Options) {
+	return WithElementSeparatorString(string(r))
+}
+
+// WithElementSeparatorString is like WithElementSeparator, but takes a
+// separator of any length, as in ", " or "\n  ".
+func WithElementSeparatorString(sep string) func(*Options) {
 	return func(o *Options) {
-		o.elemSep = r
+		o.elemSep = sep
 	}
 }
 
 // WithKeySeparator override the default key separator used for
-// parsing/stringifying key, value pairs in maps.
+// parsing/stringifying key, value pairs in maps. Use WithKeySeparatorString
+// for separators of more than one rune. Map items are split at their first
+// key separator, so that values, but not keys, may contain it. Stringify
+// errors on keys containing the key separator, unless they are quoted using
+// the WithCSVElements option.
 func WithKeySeparator(r rune) func(*Options) {
+	return WithKeySeparatorString(string(r))
+}
+
+// WithKeySeparatorString is like WithKeySeparator, but takes a separator of
+// any length, as in " => ".
+func WithKeySeparatorString(sep string) func(*Options) {
+	return func(o *Options) {
+		o.keySep = sep
+	}
+}
+
+// WithTrimSpace makes [Parse] tolerate any amount of white space around
+// separators, by splitting on the separators with leading and trailing white
+// space removed, and removing leading and trailing white space from elements,
+// keys and values. Separators consisting of white space only, such as "\n  ",
+// are split on as is. [Stringify] writes the separators as is.
+func WithTrimSpace() func(*Options) {
 	return func(o *Options) {
-		o.keySep = r
+		o.trimSpace = true
 	}
 }
 
 func buildOptions(optFns []func(*Options)) Options {
 	opts := Options{
-		elemSep: ';',
-		keySep:  ':',
+		elemSep: ";",
+		keySep:  ":",
 	}
 	for _, fn := range optFns {
 		fn(&opts)
 	}
+	if err := checkSeparators(&opts); err != nil {
+		opts.savedErr = errors.Join(opts.savedErr, err)
+	}
+	if opts.csv && opts.shellWords {
+		opts.savedErr = errors.Join(opts.savedErr, errors.New("WithCSVElements and WithShellWords cannot be combined"))
+	}
+	if opts.nilLitSet && opts.emptyLitSet && opts.nilLit == opts.emptyLit {
+		opts.savedErr = errors.Join(opts.savedErr, fmt.Errorf("nil literal and empty literal are both %q", opts.nilLit))
+	}
 	return opts
 }
+
+// optionsFor builds the options of optFns for an exported function that
+// converts values of the types typs, and checks the separators against them
+// as described by checkTarget. Nil types are skipped. It returns the errors
+// of the options, if any.
+func optionsFor(optFns []func(*Options), typs ...reflect.Type) (Options, error) {
+	opts := buildOptions(optFns)
+	for _, typ := range typs {
+		if typ != nil {
+			checkTarget(typ, &opts)
+		}
+	}
+	return opts, opts.savedErr
+}
+
+// checkSeparators checks that the element and key separators are non-empty.
+// Whether they overlap only matters for maps, and is checked by checkTarget.
+func checkSeparators(o *Options) error {
+	switch {
+	case o.elemSep == "":
+		return errors.New("empty element separator")
+	case o.keySep == "":
+		return errors.New("empty key separator")
+	}
+	return nil
+}
+
+// overlaps reports whether either of the separators a and b contains the
+// other, so that splitting on one would also split the other.
+func overlaps(a, b string) bool {
+	return strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+// effectiveSep returns the separator to split on for the separator sep.
+func (o *Options) effectiveSep(sep string) string {
+	if !o.trimSpace || strings.TrimSpace(sep) == "" {
+		return sep
+	}
+	return strings.TrimSpace(sep)
+}
@@ -0,0 +1,136 @@
+package strconvert
+
+import "reflect"
+
+// OrderedMap is a map that remembers the order in which its keys were first
+// set. [Parse] and [Stringify] convert ordered maps in the same way as maps,
+// except that entries keep the order of the input, rather than being sorted
+// by key. The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	keys []K
+	m    map[K]V
+}
+
+// Set sets the value of k to v. Setting the value of an existing key keeps
+// its position.
+func (om *OrderedMap[K, V]) Set(k K, v V) {
+	if om.m == nil {
+		om.m = make(map[K]V)
+	}
+	if _, ok := om.m[k]; !ok {
+		om.keys = append(om.keys, k)
+	}
+	om.m[k] = v
+}
+
+// Get returns the value of k, and reports whether k is present.
+func (om *OrderedMap[K, V]) Get(k K) (V, bool) {
+	v, ok := om.m[k]
+	return v, ok
+}
+
+// Delete removes k, if present.
+func (om *OrderedMap[K, V]) Delete(k K) {
+	if _, ok := om.m[k]; !ok {
+		return
+	}
+	delete(om.m, k)
+	for i, key := range om.keys {
+		if key == k {
+			om.keys = append(om.keys[:i:i], om.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Keys returns the keys in order.
+func (om *OrderedMap[K, V]) Keys() []K {
+	return append([]K(nil), om.keys...)
+}
+
+// Len returns the number of keys.
+func (om *OrderedMap[K, V]) Len() int {
+	return len(om.keys)
+}
+
+// orderedMap is implemented by pointers to OrderedMap types.
+type orderedMap interface {
+	entryTypes() (key, elem reflect.Type)
+	entries() (keys, elems []reflect.Value)
+	hasEntry(k reflect.Value) bool
+	setEntry(k, v reflect.Value)
+}
+
+var orderedMapType = reflect.TypeOf((*orderedMap)(nil)).Elem()
+
+func (om *OrderedMap[K, V]) entryTypes() (key, elem reflect.Type) {
+	return reflect.TypeOf((*K)(nil)).Elem(), reflect.TypeOf((*V)(nil)).Elem()
+}
+
+func (om *OrderedMap[K, V]) entries() (keys, elems []reflect.Value) {
+	for _, k := range om.keys {
+		k, v := k, om.m[k]
+		keys = append(keys, reflect.ValueOf(&k).Elem())
+		elems = append(elems, reflect.ValueOf(&v).Elem())
+	}
+	return keys, elems
+}
+
+func (om *OrderedMap[K, V]) hasEntry(k reflect.Value) bool {
+	key, _ := k.Interface().(K)
+	_, ok := om.m[key]
+	return ok
+}
+
+func (om *OrderedMap[K, V]) setEntry(k, v reflect.Value) {
+	key, _ := k.Interface().(K)
+	val, _ := v.Interface().(V)
+	om.Set(key, val)
+}
+
+// isOrderedMap reports whether typ is an OrderedMap type.
+func isOrderedMap(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(orderedMapType)
+}
+
+// asOrderedMap returns the ordered map v as an orderedMap, copying v if it is
+// not addressable.
+func asOrderedMap(v reflect.Value) orderedMap {
+	if !v.CanAddr() {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p.Elem()
+	}
+	return v.Addr().Interface().(orderedMap)
+}
+
+func parseOrderedMap(s string, v reflect.Value, opts *Options) error {
+	p := reflect.New(v.Type())
+	om := p.Interface().(orderedMap)
+	if opts.mergeMaps {
+		// Copy, so that other references to v are left unchanged.
+		keys, elems := asOrderedMap(v).entries()
+		for i := range keys {
+			om.setEntry(keys[i], elems[i])
+		}
+	}
+	key, elem := om.entryTypes()
+	if err := parsePairs(s, key, elem, opts, om.hasEntry, om.setEntry); err != nil {
+		return err
+	}
+	v.Set(p.Elem())
+	return nil
+}
+
+func stringifyOrderedMap(v reflect.Value, opts *Options) (string, error) {
+	keys, elems := asOrderedMap(v).entries()
+	entries := make([]mapEntry, len(keys))
+	for i := range keys {
+		e, err := stringifyEntry(keys[i], elems[i], opts)
+		if err != nil {
+			return "", err
+		}
+		entries[i] = e
+	}
+	return joinEntries(entries, opts), nil
+}
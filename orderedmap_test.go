@@ -0,0 +1,95 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nahojer/strconvert"
+)
+
+func orderedMap[K comparable, V any](pairs ...any) strconvert.OrderedMap[K, V] {
+	var om strconvert.OrderedMap[K, V]
+	for i := 0; i < len(pairs); i += 2 {
+		om.Set(pairs[i].(K), pairs[i+1].(V))
+	}
+	return om
+}
+
+func testParseOrderedMap[K comparable, V any](t *testing.T, in string, want strconvert.OrderedMap[K, V], optFns ...func(*strconvert.Options)) {
+	var got strconvert.OrderedMap[K, V]
+	if err := strconvert.Parse(in, reflect.ValueOf(&got).Elem(), optFns...); err != nil {
+		t.Fatalf("Parse(%q, <OrderedMap>) = %q", in, err)
+	}
+	if !cmp.Equal(got.Keys(), want.Keys(), cmp.Comparer(func(a, b K) bool { return a == b })) {
+		t.Errorf("Parse(%q, <OrderedMap>) has keys %v; want %v", in, got.Keys(), want.Keys())
+	}
+	for _, k := range want.Keys() {
+		gv, _ := got.Get(k)
+		wv, _ := want.Get(k)
+		if !cmp.Equal(gv, wv) {
+			t.Errorf("Parse(%q, <OrderedMap>) has %v:%v; want %v", in, k, gv, wv)
+		}
+	}
+}
+
+func TestOrderedMap(t *testing.T) {
+	var om strconvert.OrderedMap[string, int]
+	om.Set("z", 1)
+	om.Set("a", 2)
+	om.Set("m", 3)
+	om.Set("z", 4)
+	if got, want := om.Keys(), []string{"z", "a", "m"}; !cmp.Equal(got, want) {
+		t.Errorf("Keys() = %v; want %v", got, want)
+	}
+	if v, ok := om.Get("z"); v != 4 || !ok {
+		t.Errorf("Get(\"z\") = %d, %t; want 4, true", v, ok)
+	}
+	om.Delete("a")
+	om.Delete("missing")
+	if got, want := om.Keys(), []string{"z", "m"}; !cmp.Equal(got, want) || om.Len() != 2 {
+		t.Errorf("Keys() = %v; want %v", got, want)
+	}
+	if _, ok := om.Get("a"); ok {
+		t.Errorf("Get(\"a\") = _, true; want false")
+	}
+
+	testStringify(t, om, "z:4;m:3")
+	testStringify(t, &om, "z:4;m:3")
+	testStringify(t, strconvert.OrderedMap[string, int]{}, "")
+	testStringify(t, strconvert.OrderedMap[string, int]{}, "{}", strconvert.WithEmptyLiteral("{}"))
+	testStringify(t, orderedMap[int, float64](3, 0.5, 1, 2.5), "3:0.5;1:2.5")
+
+	testParseOrderedMap(t, "z:1;a:2;m:3", orderedMap[string, int]("z", 1, "a", 2, "m", 3))
+	testParseOrderedMap(t, "b:1;a:2;b:3", orderedMap[string, int]("b", 3, "a", 2))
+	testParseOrderedMap(t, "b:1;a:2;b:3", orderedMap[string, int]("b", 1, "a", 2),
+		strconvert.WithDuplicateKeys(strconvert.DuplicateKeep))
+	testParseOrderedMap(t, "", strconvert.OrderedMap[string, int]{})
+	testParseOrderedMap(t, "2:x;1:y", orderedMap[int, string](2, "x", 1, "y"))
+
+	merged := orderedMap[string, int]("b", 1)
+	if err := strconvert.Parse("a:2", reflect.ValueOf(&merged).Elem(), strconvert.WithMergeMaps()); err != nil {
+		t.Fatalf("Parse(\"a:2\", <OrderedMap>, WithMergeMaps()) = %q", err)
+	}
+	if got, want := merged.Keys(), []string{"b", "a"}; !cmp.Equal(got, want) {
+		t.Errorf("Parse(\"a:2\", <OrderedMap>, WithMergeMaps()) has keys %v; want %v", got, want)
+	}
+
+	var bad strconvert.OrderedMap[string, int]
+	if err := strconvert.Parse("a:1;a:2", reflect.ValueOf(&bad).Elem(), strconvert.WithDuplicateKeys(strconvert.DuplicateError)); err == nil {
+		t.Errorf("Parse(\"a:1;a:2\", <OrderedMap>, DuplicateError) = nil; want error")
+	}
+
+	type Config struct {
+		_     struct{} `strconvert:",tuple"`
+		Name  string
+		Steps strconvert.OrderedMap[string, int]
+	}
+	var c Config
+	if err := strconvert.Parse("x;b:1", reflect.ValueOf(&c).Elem()); err != nil {
+		t.Fatalf("Parse(\"x;b:1\", <tuple>) = %q", err)
+	}
+	if v, _ := c.Steps.Get("b"); c.Name != "x" || v != 1 {
+		t.Errorf("Parse(\"x;b:1\", <tuple>) = %v", c)
+	}
+}
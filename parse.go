@@ -9,6 +9,8 @@ import (
 	"time"
 )
 
+var durationType = reflect.TypeOf(time.Duration(0))
+
 // ErrInvalidParseArgument describes an in inalid argument parsed to Parse.
 // (The argument to Parse must be addressable as defined by the reflect package.)
 var ErrInvalidParseArgument = errors.New("")
@@ -27,29 +29,96 @@ var ErrInvalidParseArgument = errors.New("")
 //   - ~string
 //   - ~int, ~int8, ~int16, ~int32, ~int64
 //   - [time.Duration]
+//   - [net.IPNet] (CIDR notation, keeping the host address, as in
+//     "10.1.2.3/8"), [net.HardwareAddr], [net.TCPAddr] and
+//     [net.UDPAddr] (host:port with an IP address as host)
+//   - [fs.FileMode] (octal with a leading zero, as in "0644", symbolic, as
+//     in "rwxr-xr-x" or "u=rw,g=r", or decimal without a leading zero, as in
+//     "420")
 //   - ~uint, ~uint8, ~uint16, ~uint32, ~uint64
 //   - ~float32, ~float64
 //   - ~complex64, ~complex128
 //   - ~bool
-//   - Any pointer to the above types
+//   - Pointers of any depth to the above types, where parsers registered
+//     for a type also apply to pointers to it
 //   - slices, arrays and maps of any of the above types
+//   - [OrderedMap] types of any of the above types
+//   - []byte, named byte slice types and byte arrays, encoded as set using
+//     the WithBytesEncoding option (raw bytes for slices and hexadecimal for
+//     arrays by default)
+//   - [UUID]
+//   - Tuple structs whose fields are any of the above types
+//
+// Use the WithNilLiteral and WithEmptyLiteral options to tell nil, empty and
+// zero values apart.
+//
+// A struct type is a tuple if it declares a blank field tagged with
+// `strconvert:",tuple"`. Tuples are parsed positionally into their exported
+// fields in declaration order, with elements separated in the same way as
+// slice elements. Trailing fields may be omitted from the input, in which
+// case they are set to their default value (see [ApplyDefaults]), if any, or
+// else left untouched.
 //
 // Parse errors for any unsupported type. More types may be be supported in
 // the future.
+//
+// After parsing, the value is checked against the rules set using the
+// WithValidation option and the rules declared in the "validate" struct tags
+// of tuple fields. See [Validate].
 func Parse(s string, v reflect.Value, optFns ...func(*Options)) error {
-	opts := buildOptions(optFns)
-	if opts.savedErr != nil {
-		return opts.savedErr
+	var typ reflect.Type
+	if v.IsValid() {
+		typ = v.Type()
+	}
+	opts, err := optionsFor(optFns, typ)
+	if err != nil {
+		return err
 	}
 	if !v.CanAddr() {
 		return ErrInvalidParseArgument
 	}
-	return parse(s, v, &opts)
+	if err := parse(s, v, &opts); err != nil {
+		return err
+	}
+	return validate(v, opts.rules, &opts)
 }
 
 func parse(s string, v reflect.Value, opts *Options) error {
 	typ := v.Type()
 
+	if opts.lookup != nil && !opts.expanded {
+		// Expand the input as a whole, before it is split into elements, so
+		// that variables may expand to several elements and defaults may
+		// contain separators.
+		var err error
+		if s, err = interpolate(s, opts.lookup); err != nil {
+			return err
+		}
+		eo := *opts
+		eo.expanded = true
+		opts = &eo
+	}
+
+	if opts.resolvers != nil && isLeaf(typ, opts) {
+		var err error
+		if s, err = resolve(s, opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.nilLitSet && s == opts.nilLit && isNilable(typ) {
+		v.Set(reflect.Zero(typ))
+		return nil
+	}
+
+	return parseValue(s, v, opts)
+}
+
+// parseValue parses s into v, which may be a pointer of any depth. Unlike
+// parse, it does not preprocess s.
+func parseValue(s string, v reflect.Value, opts *Options) error {
+	typ := v.Type()
+
 	if fn, ok := opts.funcs[typ]; ok {
 		out := fn.Call([]reflect.Value{reflect.ValueOf(s)})
 		if err, _ := out[1].Interface().(error); err != nil {
@@ -59,6 +128,16 @@ func parse(s string, v reflect.Value, opts *Options) error {
 		return nil
 	}
 
+	if typ.Kind() == reflect.Ptr && v.IsNil() {
+		// Allocate, rather than calling the methods of a nil pointer.
+		v.Set(reflect.New(typ.Elem()))
+	}
+
+	if typ.Kind() == reflect.Ptr && hasFunc(typ.Elem(), opts) {
+		// Parsers of the element type take precedence over unmarshalers.
+		return parseValue(s, v.Elem(), opts)
+	}
+
 	if t := textUnmarshaler(v); t != nil {
 		return t.UnmarshalText([]byte(s))
 	}
@@ -68,11 +147,55 @@ func parse(s string, v reflect.Value, opts *Options) error {
 	}
 
 	if typ.Kind() == reflect.Ptr {
-		typ = typ.Elem()
-		if v.IsNil() {
-			v.Set(reflect.New(typ))
+		// Look for parsers and unmarshalers of the element type as well.
+		return parseValue(s, v.Elem(), opts)
+	}
+
+	if ok, err := parseNet(s, v); ok {
+		return err
+	}
+
+	if opts.ranges && isRangeType(typ) {
+		return parseRanges(s, v)
+	}
+
+	if scale, ok := opts.fixed[typ]; ok {
+		n, err := parseFixed(s, scale, opts.rounding)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+		return nil
+	}
+
+	if opts.expressions && isExpressionType(typ) && isExpression(s, typ) {
+		return parseExpression(s, v)
+	}
+
+	if opts.quantities && isQuantityType(typ) {
+		return parseQuantity(s, v)
+	}
+
+	if typ == fileModeType {
+		m, err := parseFileMode(s)
+		if err != nil {
+			return err
 		}
-		v = v.Elem()
+		v.Set(reflect.ValueOf(m))
+		return nil
+	}
+
+	if opts.emptyLitSet && s == opts.emptyLit && isCollection(typ) {
+		parseEmpty(v, opts)
+		return nil
+	}
+
+	if isOrderedMap(typ) {
+		return parseOrderedMap(s, v, opts)
+	}
+
+	if isBytes(typ) {
+		return parseBytes(s, v, opts)
 	}
 
 	switch typ.Kind() {
@@ -130,21 +253,23 @@ func parse(s string, v reflect.Value, opts *Options) error {
 		v.SetBool(b)
 
 	case reflect.Slice:
-		if typ.Elem().Kind() == reflect.Uint8 {
-			v.Set(reflect.ValueOf([]byte(s)))
-		} else {
-			elems := strings.Split(s, string(opts.elemSep))
-			sl := reflect.MakeSlice(typ, len(elems), len(elems))
-			for i, val := range elems {
-				if err := parse(val, sl.Index(i), opts); err != nil {
-					return err
-				}
+		elems, err := splitElems(s, opts)
+		if err != nil {
+			return err
+		}
+		sl := reflect.MakeSlice(typ, len(elems), len(elems))
+		for i, val := range elems {
+			if err := parse(val, sl.Index(i), opts); err != nil {
+				return err
 			}
-			v.Set(sl)
 		}
+		v.Set(appendElems(v, sl, opts))
 
 	case reflect.Array:
-		elems := strings.Split(s, string(opts.elemSep))
+		elems, err := splitElems(s, opts)
+		if err != nil {
+			return err
+		}
 		if len(elems) > v.Cap() {
 			return fmt.Errorf("number of elements (%d) exceeds array capacity (%d)", len(elems), v.Cap())
 		}
@@ -158,27 +283,160 @@ func parse(s string, v reflect.Value, opts *Options) error {
 		}
 
 	case reflect.Map:
-		m := reflect.MakeMap(typ)
-		if len(strings.TrimSpace(s)) != 0 {
-			pairs := strings.Split(s, string(opts.elemSep))
-			for _, pair := range pairs {
-				kvpair := strings.Split(pair, string(opts.keySep))
-				if len(kvpair) != 2 {
-					return fmt.Errorf("invalid map item: %q", pair)
-				}
-				k := reflect.New(typ.Key()).Elem()
-				if err := parse(kvpair[0], k, opts); err != nil {
-					return err
-				}
-				v := reflect.New(typ.Elem()).Elem()
-				if err := parse(kvpair[1], v, opts); err != nil {
+		m := existingMap(v, opts)
+		has := func(k reflect.Value) bool { return m.MapIndex(k).IsValid() }
+		set := func(k, v reflect.Value) { m.SetMapIndex(k, v) }
+		if err := parsePairs(s, typ.Key(), typ.Elem(), opts, has, set); err != nil {
+			return err
+		}
+		v.Set(m)
+
+	case reflect.Struct:
+		if !isTuple(typ) {
+			break
+		}
+		fields := structFields(typ)
+		var elems []string
+		if s != "" {
+			var err error
+			if elems, err = splitElems(s, opts); err != nil {
+				return err
+			}
+		}
+		if len(elems) > len(fields) {
+			return fmt.Errorf("number of elements (%d) exceeds number of tuple fields (%d)", len(elems), len(fields))
+		}
+		for i, f := range fields {
+			fv := v.FieldByIndex(f.Index)
+			if i >= len(elems) {
+				if err := setDefault(f, fv, opts); err != nil {
 					return err
 				}
-				m.SetMapIndex(k, v)
+				continue
+			}
+			if err := parse(elems[i], fv, fieldOptions(f, opts)); err != nil {
+				return fmt.Errorf("error parsing tuple field %s: %w", f.Name, err)
 			}
 		}
-		v.Set(m)
 	}
 
 	return nil
 }
+
+// parsePairs parses the key, value pairs of s into values of the types key
+// and elem, and calls set for each pair, unless has reports that its key is
+// already present and the WithDuplicateKeys option says otherwise.
+func parsePairs(s string, key, elem reflect.Type, opts *Options, has func(k reflect.Value) bool, set func(k, v reflect.Value)) error {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil
+	}
+	pairs, err := splitElems(s, opts)
+	if err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		kvpair := splitPair(pair, opts)
+		if len(kvpair) != 2 {
+			return fmt.Errorf("invalid map item: %q", pair)
+		}
+		k := reflect.New(key).Elem()
+		if err := parse(kvpair[0], k, opts); err != nil {
+			return err
+		}
+		v := reflect.New(elem).Elem()
+		if err := parse(kvpair[1], v, opts); err != nil {
+			return err
+		}
+		if opts.dupKeys != DuplicateOverwrite && has(k) {
+			if opts.dupKeys == DuplicateError {
+				return fmt.Errorf("duplicate map key %q", kvpair[0])
+			}
+			continue
+		}
+		set(k, v)
+	}
+	return nil
+}
+
+// hasFunc reports whether a parser or stringifier is registered for typ or,
+// if typ is a pointer, for any of the types it points to.
+func hasFunc(typ reflect.Type, opts *Options) bool {
+	for {
+		if _, ok := opts.funcs[typ]; ok {
+			return true
+		}
+		if typ.Kind() != reflect.Ptr {
+			return false
+		}
+		typ = typ.Elem()
+	}
+}
+
+// isLeaf reports whether values of typ are parsed from the input as a whole,
+// rather than from its elements.
+func isLeaf(typ reflect.Type, opts *Options) bool {
+	for {
+		if _, ok := opts.funcs[typ]; ok {
+			return true
+		}
+		ptr := reflect.PointerTo(typ)
+		if ptr.Implements(textUnmarshalerType) || ptr.Implements(binaryUnmarshalerType) {
+			return true
+		}
+		if typ.Kind() != reflect.Ptr {
+			break
+		}
+		typ = typ.Elem()
+	}
+
+	if opts.ranges && isRangeType(typ) || isBytes(typ) {
+		return true
+	}
+
+	if isOrderedMap(typ) {
+		return false
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return false
+	case reflect.Struct:
+		return !isTuple(typ)
+	}
+	return true
+}
+
+// splitElems splits s into the elements of a slice, array, map or tuple.
+func splitElems(s string, opts *Options) ([]string, error) {
+	if opts.csv {
+		return splitCSV(s, opts)
+	}
+	if opts.shellWords {
+		return splitShellWords(s)
+	}
+	return splitSep(s, opts.elemSep, -1, opts), nil
+}
+
+// splitPair splits a map item into its key and value at the first key
+// separator, so that values may contain the key separator. It returns a
+// single part if there is no key separator.
+func splitPair(s string, opts *Options) []string {
+	if opts.csv {
+		if parts, ok := splitQuotedPair(s, opts); ok {
+			return parts
+		}
+	}
+	return splitSep(s, opts.keySep, 2, opts)
+}
+
+// splitSep splits s into at most n parts separated by sep, or into all parts
+// if n is negative.
+func splitSep(s, sep string, n int, opts *Options) []string {
+	parts := strings.SplitN(s, opts.effectiveSep(sep), n)
+	if opts.trimSpace {
+		for i, p := range parts {
+			parts[i] = strings.TrimSpace(p)
+		}
+	}
+	return parts
+}
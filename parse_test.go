@@ -65,6 +65,18 @@ func TestParse(t *testing.T) {
 		testParse(t, "item1;item2", [10]string{"item1", "item2"})
 	})
 
+	t.Run("tuple", func(t *testing.T) {
+		testParse(t, "10.0.0.1;8080;0.5", Backend{Host: "10.0.0.1", Port: 8080, Weight: 0.5})
+		testParse(t, "10.0.0.1;8080", Backend{Host: "10.0.0.1", Port: 8080})
+		testParse(t, "", Backend{})
+		testParse(t, "a|1", Backend{Host: "a", Port: 1}, strconvert.WithElementSeparator('|'))
+
+		var b Backend
+		if err := strconvert.Parse("a;1;2;3", reflect.ValueOf(&b).Elem()); err == nil {
+			t.Errorf("Parse(\"a;1;2;3\", <tuple>) = nil; want error")
+		}
+	})
+
 	t.Run("text unmarshaler", func(t *testing.T) {
 		testParse(t, "some text", TextStruct{"some text"})
 	})
@@ -86,6 +98,37 @@ func TestParse(t *testing.T) {
 		}))
 	})
 
+	t.Run("pointers", func(t *testing.T) {
+		n := 42
+		pn := &n
+		testParse(t, "42", &n)
+		testParse(t, "42", &pn)
+		testParse(t, "1;2", &[]int{1, 2})
+		testParse(t, "a:1", &map[string]int{"a": 1})
+		testParse(t, "some text", &TextStruct{"some text"})
+		u := strconvert.UUID{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}
+		pu := &u
+		testParse(t, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", &pu)
+
+		x := 3.14159
+		px := &x
+		parser := strconvert.WithParser(func(s string) (float64, error) {
+			return strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+		})
+		testParse(t, "3,14159", &x, parser)
+		testParse(t, "3,14159", &px, parser)
+		testParse(t, "3,14159", []*float64{&x}, parser)
+
+		// Parsers take precedence over the unmarshalers of time.Time.
+		tm := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		ptm := &tm
+		date := strconvert.WithParser(func(s string) (time.Time, error) {
+			return time.Parse("2006-01-02", s)
+		})
+		testParse(t, "2024-01-02", &tm, date)
+		testParse(t, "2024-01-02", &ptm, date)
+	})
+
 	t.Run("addressable struct field", func(t *testing.T) {
 		strct := &struct{ Got float64 }{}
 		kind := reflect.TypeOf(strct.Got).Kind()
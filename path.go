@@ -0,0 +1,264 @@
+package strconvert
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxPathIndex limits the index of slice elements set by SetPath, as slices
+// are grown to fit the index.
+const maxPathIndex = 1 << 16
+
+// SetPath parses value into the element of root addressed by path. If root is
+// not a non-nil pointer, SetPath returns an ErrInvalidParseArgument.
+//
+// A path is a sequence of struct field names separated by dots, each
+// optionally followed by one or more bracketed indexes, as in
+// "db.replicas[2].host" or "labels[env]". Field names are matched case
+// insensitively. Brackets index slices, arrays and maps; map keys are parsed
+// into the key type of the map and may also be given as dot-separated
+// segments, as in "labels.env". A backslash escapes the character following
+// it, so that field names and map keys may contain dots and brackets, as in
+// `labels.kubernetes\.io/role`. An empty path addresses root itself.
+//
+// Nil pointers and maps along the path are allocated, and slices are grown to
+// fit the index being set, up to an index of 65536. The value at the end of
+// the path is parsed in the same way as [Parse] would, using the same
+// options. Only the separators of the value at the end of the path are
+// checked for conflicts.
+func SetPath(root any, path string, value string, optFns ...func(*Options)) error {
+	return setPath(root, path, optFns, func(v reflect.Value, opts *Options) error {
+		if err := targetConflicts(v.Type(), opts); err != nil {
+			return err
+		}
+		return parse(value, v, opts)
+	})
+}
+
+// setPath calls leaf with the element of root addressed by path, allocating
+// along the path as described for SetPath. Checking the separators against
+// the element is left to leaf.
+func setPath(root any, path string, optFns []func(*Options), leaf func(reflect.Value, *Options) error) error {
+	opts, err := optionsFor(optFns)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(root)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrInvalidParseArgument
+	}
+	segs, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if err := walkPath(v.Elem(), segs, true, &opts, leaf); err != nil {
+		return fmt.Errorf("error setting path %q: %w", path, err)
+	}
+	return nil
+}
+
+// GetPath stringifies the element of root addressed by path. See [SetPath] for
+// the path syntax. Unlike SetPath, GetPath never allocates and errors if the
+// path runs into a nil pointer, a missing map key or an out of range index.
+//
+// The value at the end of the path is stringified in the same way as
+// [Stringify] would, using the same options.
+func GetPath(root any, path string, optFns ...func(*Options)) (string, error) {
+	opts, err := optionsFor(optFns)
+	if err != nil {
+		return "", err
+	}
+	segs, err := splitPath(path)
+	if err != nil {
+		return "", err
+	}
+	v := reflect.ValueOf(root)
+	if !v.IsValid() {
+		return "", ErrInvalidParseArgument
+	}
+	var s string
+	err = walkPath(v, segs, false, &opts, func(v reflect.Value, opts *Options) (err error) {
+		if err := targetConflicts(v.Type(), opts); err != nil {
+			return err
+		}
+		s, err = stringify(v, opts)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting path %q: %w", path, err)
+	}
+	return s, nil
+}
+
+// pathSegment is a single step of a path, either a field name (or map key)
+// given after a dot or a bracketed index.
+type pathSegment struct {
+	name    string
+	bracket bool
+}
+
+func (seg pathSegment) String() string {
+	if seg.bracket {
+		return "[" + seg.name + "]"
+	}
+	return seg.name
+}
+
+func splitPath(path string) ([]pathSegment, error) {
+	var segs []pathSegment
+	rest := path
+	for rest != "" {
+		if rest[0] == '[' {
+			name, after, ok := cutSegment(rest[1:], "]")
+			if !ok {
+				return nil, fmt.Errorf("invalid path %q: trailing backslash", path)
+			}
+			if after == "" {
+				return nil, fmt.Errorf("invalid path %q: missing ]", path)
+			}
+			segs = append(segs, pathSegment{name: name, bracket: true})
+			rest = after[1:]
+			if rest != "" && rest[0] != '[' {
+				if rest[0] != '.' {
+					return nil, fmt.Errorf("invalid path %q: unexpected %q after ]", path, rest[0])
+				}
+				rest = rest[1:]
+				if rest == "" {
+					return nil, fmt.Errorf("invalid path %q: trailing dot", path)
+				}
+			}
+			continue
+		}
+
+		name, after, ok := cutSegment(rest, ".[")
+		if !ok {
+			return nil, fmt.Errorf("invalid path %q: trailing backslash", path)
+		}
+		if len(after) == len(rest) {
+			return nil, fmt.Errorf("invalid path %q: empty segment", path)
+		}
+		segs = append(segs, pathSegment{name: name})
+		rest = after
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			if rest == "" {
+				return nil, fmt.Errorf("invalid path %q: trailing dot", path)
+			}
+		}
+	}
+	return segs, nil
+}
+
+// cutSegment returns the segment at the start of s, up to the first unescaped
+// byte of stop, with escape sequences replaced by the escaped characters, and
+// the rest of s starting at that byte. It reports false if s ends in a
+// backslash.
+func cutSegment(s, stop string) (seg, rest string, ok bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			if i+1 == len(s) {
+				return "", "", false
+			}
+			i++
+			b.WriteByte(s[i])
+		case strings.IndexByte(stop, c) >= 0:
+			return b.String(), s[i:], true
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), "", true
+}
+
+// walkPath follows segs from v and calls leaf with the value at the end of the
+// path, along with the options for converting it. If set is true, nil
+// pointers and maps are allocated, slices are grown and map elements are
+// stored back into their maps after leaf returns.
+func walkPath(v reflect.Value, segs []pathSegment, set bool, opts *Options, leaf func(reflect.Value, *Options) error) error {
+	if len(segs) == 0 {
+		return leaf(v, opts)
+	}
+	seg := segs[0]
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			if !set {
+				return fmt.Errorf("nil pointer at %s", seg)
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return walkPath(v.Elem(), segs, set, opts, leaf)
+
+	case reflect.Interface:
+		if v.IsNil() || set {
+			return fmt.Errorf("cannot index %s with %s", v.Type(), seg)
+		}
+		return walkPath(v.Elem(), segs, set, opts, leaf)
+
+	case reflect.Struct:
+		if seg.bracket {
+			return fmt.Errorf("cannot index struct %s with %s", v.Type(), seg)
+		}
+		for _, f := range structFields(v.Type()) {
+			if strings.EqualFold(f.Name, seg.name) {
+				if _, _, err := tagBytesEncoding(f.Tag.Get("strconvert")); err != nil {
+					return fmt.Errorf("field %s of %s: %w", f.Name, v.Type(), err)
+				}
+				return walkPath(v.FieldByIndex(f.Index), segs[1:], set, fieldOptions(f, opts), leaf)
+			}
+		}
+		return fmt.Errorf("no field %q in struct %s", seg.name, v.Type())
+
+	case reflect.Slice, reflect.Array:
+		if !seg.bracket {
+			return fmt.Errorf("cannot select %q from %s", seg.name, v.Type())
+		}
+		i, err := strconv.Atoi(seg.name)
+		if err != nil || i < 0 {
+			return fmt.Errorf("invalid index %s", seg)
+		}
+		if i >= v.Len() {
+			if !set || v.Kind() == reflect.Array {
+				return fmt.Errorf("index %s out of range (length %d)", seg, v.Len())
+			}
+			if i > maxPathIndex {
+				return fmt.Errorf("index %s exceeds the maximum of %d", seg, maxPathIndex)
+			}
+			v.Set(reflect.AppendSlice(v, reflect.MakeSlice(v.Type(), i+1-v.Len(), i+1-v.Len())))
+		}
+		return walkPath(v.Index(i), segs[1:], set, opts, leaf)
+
+	case reflect.Map:
+		typ := v.Type()
+		k := reflect.New(typ.Key()).Elem()
+		if err := targetConflicts(typ.Key(), opts); err != nil {
+			return fmt.Errorf("invalid map key %s: %w", seg, err)
+		}
+		if err := parse(seg.name, k, opts); err != nil {
+			return fmt.Errorf("invalid map key %s: %w", seg, err)
+		}
+		elem := reflect.New(typ.Elem()).Elem()
+		if existing := v.MapIndex(k); existing.IsValid() {
+			elem.Set(existing)
+		} else if !set {
+			return fmt.Errorf("map key %s not found", seg)
+		}
+		if err := walkPath(elem, segs[1:], set, opts, leaf); err != nil {
+			return err
+		}
+		if set {
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(typ))
+			}
+			v.SetMapIndex(k, elem)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("cannot index %s with %s", v.Type(), seg)
+}
@@ -0,0 +1,90 @@
+package strconvert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nahojer/strconvert"
+)
+
+type Replica struct {
+	Host string
+	Port uint16
+}
+
+type PathConfig struct {
+	DB struct {
+		Timeout  time.Duration
+		Replicas []Replica
+		Primary  *Replica
+	}
+	Labels  map[string]string
+	Weights map[string][3]int
+}
+
+func TestSetPath(t *testing.T) {
+	var got PathConfig
+	sets := []struct{ path, value string }{
+		{"db.timeout", "5s"},
+		{"db.replicas[1].host", "replica-1"},
+		{"DB.Replicas[1].Port", "5432"},
+		{"db.primary.host", "primary"},
+		{"labels[env]", "prod"},
+		{"labels.team", "core"},
+		{"labels[dotted.key]", "yes"},
+		{`labels.kubernetes\.io/role`, "master"},
+		{`labels[a\]b]`, "c"},
+		{"weights[a][2]", "7"},
+		{"weights[a][0]", "1"},
+	}
+	for _, set := range sets {
+		if err := strconvert.SetPath(&got, set.path, set.value); err != nil {
+			t.Fatalf("SetPath(%q, %q) = %q; want nil", set.path, set.value, err)
+		}
+	}
+
+	var want PathConfig
+	want.DB.Timeout = 5 * time.Second
+	want.DB.Replicas = []Replica{{}, {Host: "replica-1", Port: 5432}}
+	want.DB.Primary = &Replica{Host: "primary"}
+	want.Labels = map[string]string{"env": "prod", "team": "core", "dotted.key": "yes", "kubernetes.io/role": "master", "a]b": "c"}
+	want.Weights = map[string][3]int{"a": {1, 0, 7}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SetPath mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, path := range []string{"db.missing", "db.replicas.host", "weights[a][3]", "db.replicas[-1]", "db..timeout", "labels[env", "db.", `labels\`, "db.replicas[65537].port"} {
+		if err := strconvert.SetPath(&got, path, "1"); err == nil {
+			t.Errorf("SetPath(%q) = nil; want error", path)
+		}
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	var cfg PathConfig
+	cfg.DB.Replicas = []Replica{{Host: "a", Port: 1}, {Host: "b", Port: 2}}
+	cfg.Labels = map[string]string{"env": "prod"}
+
+	for path, want := range map[string]string{
+		"db.replicas[1].host": "b",
+		"db.replicas[0].port": "1",
+		"labels[env]":         "prod",
+		"labels":              "env:prod",
+		"db.timeout":          "0s",
+	} {
+		got, err := strconvert.GetPath(cfg, path)
+		if err != nil {
+			t.Fatalf("GetPath(%q) = \"\", %q; want %q, nil", path, err, want)
+		}
+		if got != want {
+			t.Errorf("GetPath(%q) = %q, nil; want %q, nil", path, got, want)
+		}
+	}
+
+	for _, path := range []string{"db.replicas[2].host", "db.primary.host", "labels[team]"} {
+		if got, err := strconvert.GetPath(&cfg, path); err == nil {
+			t.Errorf("GetPath(%q) = %q, nil; want error", path, got)
+		}
+	}
+}
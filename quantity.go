@@ -0,0 +1,306 @@
+package strconvert
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// QuantityFormat is the format a Quantity is written in.
+type QuantityFormat int
+
+const (
+	// DecimalSI formats quantities using decimal SI suffixes, as in "500m",
+	// "1.5" (written as "1500m") or "100M".
+	DecimalSI QuantityFormat = iota
+	// BinarySI formats quantities using binary SI suffixes, as in "2Gi".
+	// Quantities that are not whole numbers, or that are smaller than 1024,
+	// are written using DecimalSI.
+	BinarySI
+	// DecimalExponent formats quantities using decimal exponents that are
+	// multiples of three, as in "1e3".
+	DecimalExponent
+)
+
+// maxQuantityExponent limits the magnitude of the decimal exponent of a
+// quantity.
+const maxQuantityExponent = 100
+
+var (
+	bigNano = big.NewInt(1e9)
+	big1000 = big.NewInt(1000)
+	big1024 = big.NewInt(1024)
+)
+
+var decimalSuffixes = map[string]int{
+	"n": -9, "u": -6, "m": -3, "": 0, "k": 3, "M": 6, "G": 9, "T": 12, "P": 15, "E": 18,
+}
+
+var binarySuffixes = map[string]uint{
+	"Ki": 10, "Mi": 20, "Gi": 30, "Ti": 40, "Pi": 50, "Ei": 60,
+}
+
+// Quantity is an exact number with the syntax of Kubernetes resource
+// quantities, such as "500m", "1.5", "2Gi", "100M" or "1e3". Quantities are
+// precise to nano units; finer fractions are rounded up.
+//
+// A Quantity remembers the format of its suffix, and is written in the
+// canonical form of that format, as Kubernetes would. For example, "1.5Gi"
+// is written as "1536Mi" and "0.5" as "500m". The zero Quantity is 0.
+type Quantity struct {
+	nanos  *big.Int
+	Format QuantityFormat
+}
+
+// ParseQuantity parses s as a quantity.
+func ParseQuantity(s string) (Quantity, error) {
+	num, suffix := splitQuantity(s)
+	r, ok := new(big.Rat).SetString(num)
+	if !ok || num == "" || strings.ContainsAny(num, "eE/") {
+		return Quantity{}, fmt.Errorf("invalid quantity %q", s)
+	}
+
+	q := Quantity{Format: DecimalSI}
+	switch exp, ok := decimalSuffixes[suffix]; {
+	case ok:
+		r.Mul(r, pow10(exp))
+	case binarySuffixes[suffix] != 0:
+		q.Format = BinarySI
+		r.Mul(r, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), binarySuffixes[suffix])))
+	case suffix[0] == 'e' || suffix[0] == 'E':
+		exp, err := strconv.Atoi(suffix[1:])
+		if err != nil || exp < -maxQuantityExponent || exp > maxQuantityExponent {
+			return Quantity{}, fmt.Errorf("invalid quantity %q: bad exponent", s)
+		}
+		q.Format = DecimalExponent
+		r.Mul(r, pow10(exp))
+	default:
+		return Quantity{}, fmt.Errorf("invalid quantity %q: unknown suffix %q", s, suffix)
+	}
+
+	// Round up to nano units.
+	r.Mul(r, new(big.Rat).SetInt(bigNano))
+	n, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		n.Add(n, big.NewInt(1))
+	}
+	q.nanos = n
+	return q, nil
+}
+
+// splitQuantity splits s into its signed number and its suffix.
+func splitQuantity(s string) (string, string) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func pow10(exp int) *big.Rat {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exp))), nil)
+	if exp < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), p)
+	}
+	return new(big.Rat).SetInt(p)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// NewQuantity returns a quantity of value units written in format.
+func NewQuantity(value int64, format QuantityFormat) Quantity {
+	return Quantity{nanos: new(big.Int).Mul(big.NewInt(value), bigNano), Format: format}
+}
+
+// NewMilliQuantity returns a quantity of milli thousandths of a unit written
+// in format.
+func NewMilliQuantity(milli int64, format QuantityFormat) Quantity {
+	return Quantity{nanos: new(big.Int).Mul(big.NewInt(milli), big.NewInt(1e6)), Format: format}
+}
+
+func (q Quantity) bigNanos() *big.Int {
+	if q.nanos == nil {
+		return new(big.Int)
+	}
+	return q.nanos
+}
+
+// Value returns q in units, rounded up. It errors if the value does not fit
+// in an int64.
+func (q Quantity) Value() (int64, error) {
+	return q.scaled(bigNano)
+}
+
+// MilliValue returns q in thousandths of a unit, rounded up. It errors if the
+// value does not fit in an int64.
+func (q Quantity) MilliValue() (int64, error) {
+	return q.scaled(big.NewInt(1e6))
+}
+
+func (q Quantity) scaled(div *big.Int) (int64, error) {
+	n, rem := new(big.Int).QuoRem(q.bigNanos(), div, new(big.Int))
+	if rem.Sign() > 0 {
+		n.Add(n, big.NewInt(1))
+	}
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("quantity %s overflows int64", q)
+	}
+	return n.Int64(), nil
+}
+
+// Cmp compares q and other and returns -1, 0 or +1 depending on whether q is
+// less than, equal to or greater than other.
+func (q Quantity) Cmp(other Quantity) int {
+	return q.bigNanos().Cmp(other.bigNanos())
+}
+
+// String returns q in the canonical form of its format.
+func (q Quantity) String() string {
+	n := q.bigNanos()
+	if n.Sign() == 0 {
+		return "0"
+	}
+
+	if q.Format == BinarySI {
+		units, rem := new(big.Int).QuoRem(n, bigNano, new(big.Int))
+		if rem.Sign() == 0 && new(big.Int).Abs(units).Cmp(big1024) >= 0 {
+			suffixes := []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+			i := 0
+			for ; i < len(suffixes)-1; i++ {
+				quo, rem := new(big.Int).QuoRem(units, big1024, new(big.Int))
+				if rem.Sign() != 0 {
+					break
+				}
+				units = quo
+			}
+			return units.String() + suffixes[i]
+		}
+	}
+
+	mantissa, exp := new(big.Int).Set(n), -9
+	for q.Format == DecimalExponent || exp < 18 {
+		quo, rem := new(big.Int).QuoRem(mantissa, big1000, new(big.Int))
+		if rem.Sign() != 0 {
+			break
+		}
+		mantissa, exp = quo, exp+3
+	}
+	if q.Format == DecimalExponent {
+		if exp == 0 {
+			return mantissa.String()
+		}
+		return mantissa.String() + "e" + strconv.Itoa(exp)
+	}
+	for suffix, e := range decimalSuffixes {
+		if e == exp {
+			return mantissa.String() + suffix
+		}
+	}
+	return mantissa.String()
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (q Quantity) MarshalText() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (q *Quantity) UnmarshalText(text []byte) error {
+	parsed, err := ParseQuantity(string(text))
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
+
+// WithQuantities makes [Parse] accept quantities (see [Quantity]) for
+// integer and float types, other than [time.Duration], and makes [Stringify]
+// write them in the canonical DecimalSI form, as in "100M".
+//
+// Parse errors if a quantity does not fit in an integer type, or if it is
+// not a whole number.
+func WithQuantities() func(*Options) {
+	return func(o *Options) {
+		o.quantities = true
+	}
+}
+
+// isQuantityType reports whether values of typ are converted as quantities
+// when using the WithQuantities option.
+func isQuantityType(typ reflect.Type) bool {
+	if typ == durationType {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return true
+	}
+	return isInteger(typ.Kind())
+}
+
+func parseQuantity(s string, v reflect.Value) error {
+	q, err := ParseQuantity(s)
+	if err != nil {
+		return err
+	}
+	n := q.bigNanos()
+	typ := v.Type()
+
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, _ := new(big.Rat).SetFrac(n, bigNano).Float64()
+		if v.OverflowFloat(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("quantity %s overflows %s", s, typ)
+		}
+		v.SetFloat(f)
+		return nil
+	}
+
+	units, rem := new(big.Int).QuoRem(n, bigNano, new(big.Int))
+	if rem.Sign() != 0 {
+		return fmt.Errorf("quantity %s is not a whole number", s)
+	}
+	if isSigned(typ.Kind()) {
+		if !units.IsInt64() || v.OverflowInt(units.Int64()) {
+			return fmt.Errorf("quantity %s overflows %s", s, typ)
+		}
+		v.SetInt(units.Int64())
+	} else {
+		if units.Sign() < 0 || !units.IsUint64() || v.OverflowUint(units.Uint64()) {
+			return fmt.Errorf("quantity %s overflows %s", s, typ)
+		}
+		v.SetUint(units.Uint64())
+	}
+	return nil
+}
+
+func stringifyQuantity(v reflect.Value) string {
+	q := Quantity{nanos: new(big.Int)}
+	switch {
+	case isSigned(v.Kind()):
+		q.nanos.Mul(big.NewInt(v.Int()), bigNano)
+	case isInteger(v.Kind()):
+		q.nanos.Mul(new(big.Int).SetUint64(v.Uint()), bigNano)
+	default:
+		f := strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+		fq, err := ParseQuantity(f)
+		if err != nil {
+			// NaN and infinities have no quantity representation.
+			return f
+		}
+		q = fq
+	}
+	return q.String()
+}
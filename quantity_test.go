@@ -0,0 +1,106 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestQuantity(t *testing.T) {
+	for in, want := range map[string]string{
+		"500m":     "500m",
+		"1.5":      "1500m",
+		"0.5":      "500m",
+		"2Gi":      "2Gi",
+		"1.5Gi":    "1536Mi",
+		"100M":     "100M",
+		"1000":     "1k",
+		"1500":     "1500",
+		"1e3":      "1e3",
+		"1.5e3":    "1500",
+		"12E-3":    "12e-3",
+		"512":      "512",
+		"1024":     "1024",
+		"1Ki":      "1Ki",
+		"1000Ki":   "1000Ki",
+		"0.5Ki":    "512",
+		"0.1Ki":    "102400m",
+		"0":        "0",
+		"-250m":    "-250m",
+		"+3k":      "3k",
+		"0.1n":     "1n",
+		"1234567u": "1234567u",
+		"8Ei":      "8Ei",
+		"10E":      "10E",
+		"1000E":    "1000E",
+	} {
+		q, err := strconvert.ParseQuantity(in)
+		if err != nil {
+			t.Errorf("ParseQuantity(%q) = _, %q; want nil", in, err)
+			continue
+		}
+		if got := q.String(); got != want {
+			t.Errorf("ParseQuantity(%q).String() = %q; want %q", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "m", "1.2.3", "1Ki2", "1Q", "1e", "1e1000", "1/2", "--1"} {
+		if _, err := strconvert.ParseQuantity(in); err == nil {
+			t.Errorf("ParseQuantity(%q) = _, nil; want error", in)
+		}
+	}
+
+	q, _ := strconvert.ParseQuantity("1.5")
+	if got, _ := q.MilliValue(); got != 1500 {
+		t.Errorf("ParseQuantity(\"1.5\").MilliValue() = %d; want 1500", got)
+	}
+	if got, _ := q.Value(); got != 2 {
+		t.Errorf("ParseQuantity(\"1.5\").Value() = %d; want 2", got)
+	}
+	if c := q.Cmp(strconvert.NewMilliQuantity(1500, strconvert.BinarySI)); c != 0 {
+		t.Errorf("ParseQuantity(\"1.5\").Cmp(NewMilliQuantity(1500)) = %d; want 0", c)
+	}
+	if got := strconvert.NewQuantity(3<<30, strconvert.BinarySI).String(); got != "3Gi" {
+		t.Errorf("NewQuantity(3<<30, BinarySI).String() = %q; want \"3Gi\"", got)
+	}
+	if got := (strconvert.Quantity{}).String(); got != "0" {
+		t.Errorf("Quantity{}.String() = %q; want \"0\"", got)
+	}
+}
+
+func TestQuantities(t *testing.T) {
+	type Resources struct {
+		_      struct{} `strconvert:",tuple"`
+		CPU    strconvert.Quantity
+		Memory strconvert.Quantity
+	}
+	var res Resources
+	if err := strconvert.Parse("500m;1.5Gi", reflect.ValueOf(&res).Elem()); err != nil {
+		t.Fatalf("Parse(\"500m;1.5Gi\", <Resources>) = %q; want nil", err)
+	}
+	testStringify(t, res, "500m;1536Mi")
+
+	testParse(t, "2Gi", int64(2<<30), strconvert.WithQuantities())
+	testParse(t, "1k;64", []uint16{1000, 64}, strconvert.WithQuantities())
+	testParse(t, "250m", 0.25, strconvert.WithQuantities())
+	testParse(t, "1m", time.Minute, strconvert.WithQuantities())
+	testStringify(t, int64(100_000_000), "100M", strconvert.WithQuantities())
+	testStringify(t, 1.5, "1500m", strconvert.WithQuantities())
+	testStringify(t, uint8(0), "0", strconvert.WithQuantities())
+
+	for _, tc := range []struct {
+		in string
+		v  any
+	}{
+		{"500m", new(int)},
+		{"1Mi", new(uint16)},
+		{"-1", new(uint)},
+		{"10Ei", new(int64)},
+	} {
+		if err := strconvert.Parse(tc.in, reflect.ValueOf(tc.v).Elem(), strconvert.WithQuantities()); err == nil {
+			t.Errorf("Parse(%q, <%T>, WithQuantities()) = nil; want error", tc.in, tc.v)
+		}
+	}
+}
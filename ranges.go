@@ -0,0 +1,300 @@
+package strconvert
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxRangeElems limits the number of elements a list of ranges is expanded
+// into when parsed into a slice.
+const maxRangeElems = 1 << 20
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Range is an inclusive range of integers, formatted as "lo-hi", or as "lo"
+// if lo and hi are equal. An open-ended range has no upper bound and is
+// formatted as "lo-".
+type Range[T Integer] struct {
+	Lo, Hi T
+	Open   bool
+}
+
+// Contains reports whether n is within r.
+func (r Range[T]) Contains(n T) bool {
+	return n >= r.Lo && (r.Open || n <= r.Hi)
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (r Range[T]) MarshalText() ([]byte, error) {
+	return []byte(formatRange(reflect.ValueOf(r.Lo), reflect.ValueOf(r.Hi), r.Open)), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler]. It errors if the
+// bounds of the range are out of order.
+func (r *Range[T]) UnmarshalText(text []byte) error {
+	rv, err := parseRange(string(text), reflect.TypeOf(r.Lo))
+	if err != nil {
+		return err
+	}
+	*r = Range[T]{Lo: rv.lo.Interface().(T), Hi: rv.hi.Interface().(T), Open: rv.open}
+	return nil
+}
+
+// Ranges is a list of integer ranges, formatted as comma-separated ranges,
+// as in "1-5,7,9-11" or "0-3,8". See [Range].
+type Ranges[T Integer] []Range[T]
+
+// Contains reports whether n is within any of the ranges of rs.
+func (rs Ranges[T]) Contains(n T) bool {
+	for _, r := range rs {
+		if r.Contains(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (rs Ranges[T]) MarshalText() ([]byte, error) {
+	parts := make([]string, len(rs))
+	for i, r := range rs {
+		parts[i] = formatRange(reflect.ValueOf(r.Lo), reflect.ValueOf(r.Hi), r.Open)
+	}
+	return []byte(strings.Join(parts, ",")), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (rs *Ranges[T]) UnmarshalText(text []byte) error {
+	var typ T
+	rvs, err := parseRangeList(string(text), reflect.TypeOf(typ))
+	if err != nil {
+		return err
+	}
+	ranges := make(Ranges[T], len(rvs))
+	for i, rv := range rvs {
+		ranges[i] = Range[T]{Lo: rv.lo.Interface().(T), Hi: rv.hi.Interface().(T), Open: rv.open}
+	}
+	*rs = ranges
+	return nil
+}
+
+// WithRanges makes [Parse] and [Stringify] use the range syntax of [Ranges]
+// for slices of integers, and the range syntax of [Range] for arrays of two
+// integers, instead of separating elements using the element separator.
+//
+// When parsed into a slice, ranges are expanded into all of their elements,
+// so "0-3,8" is parsed into []int{0, 1, 2, 3, 8}. Open-ended ranges cannot be
+// expanded and are rejected. When stringified, runs of consecutive elements
+// are collapsed into ranges again.
+func WithRanges() func(*Options) {
+	return func(o *Options) {
+		o.ranges = true
+	}
+}
+
+// isRangeType reports whether values of typ use the range syntax when using
+// the WithRanges option.
+func isRangeType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Slice:
+		return isInteger(typ.Elem().Kind()) && typ.Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return typ.Len() == 2 && isInteger(typ.Elem().Kind())
+	}
+	return false
+}
+
+func isInteger(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isSigned(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// rangeValue is a parsed range with bounds of an integer type.
+type rangeValue struct {
+	lo, hi reflect.Value
+	open   bool
+}
+
+func parseRangeList(s string, typ reflect.Type) ([]rangeValue, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	rvs := make([]rangeValue, len(parts))
+	for i, part := range parts {
+		rv, err := parseRange(strings.TrimSpace(part), typ)
+		if err != nil {
+			return nil, err
+		}
+		rvs[i] = rv
+	}
+	return rvs, nil
+}
+
+func parseRange(s string, typ reflect.Type) (rangeValue, error) {
+	// Skip the first character, which may be the sign of the lower bound.
+	sep := -1
+	if len(s) > 1 {
+		if i := strings.IndexByte(s[1:], '-'); i >= 0 {
+			sep = i + 1
+		}
+	}
+	loStr, hiStr := s, s
+	if sep >= 0 {
+		loStr, hiStr = s[:sep], s[sep+1:]
+	}
+
+	var rv rangeValue
+	var err error
+	if rv.lo, err = parseInteger(loStr, typ); err != nil {
+		return rv, fmt.Errorf("invalid range %q: %w", s, err)
+	}
+	if hiStr == "" {
+		rv.hi, rv.open = rv.lo, true
+		return rv, nil
+	}
+	if rv.hi, err = parseInteger(hiStr, typ); err != nil {
+		return rv, fmt.Errorf("invalid range %q: %w", s, err)
+	}
+	if compareIntegers(rv.lo, rv.hi) > 0 {
+		return rv, fmt.Errorf("invalid range %q: bounds out of order", s)
+	}
+	return rv, nil
+}
+
+func parseInteger(s string, typ reflect.Type) (reflect.Value, error) {
+	v := reflect.New(typ).Elem()
+	if isSigned(typ.Kind()) {
+		i, err := strconv.ParseInt(s, 10, typ.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(i)
+	} else {
+		u, err := strconv.ParseUint(s, 10, typ.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(u)
+	}
+	return v, nil
+}
+
+func formatInteger(v reflect.Value) string {
+	if isSigned(v.Kind()) {
+		return strconv.FormatInt(v.Int(), 10)
+	}
+	return strconv.FormatUint(v.Uint(), 10)
+}
+
+func compareIntegers(a, b reflect.Value) int {
+	if isSigned(a.Kind()) {
+		return compare(a.Int(), b.Int())
+	}
+	return compare(a.Uint(), b.Uint())
+}
+
+// successor reports whether b directly follows a. The maximum value has no
+// successor, rather than wrapping around to the minimum value.
+func successor(a, b reflect.Value) bool {
+	if isSigned(a.Kind()) {
+		return a.Int() < b.Int() && b.Int()-1 == a.Int()
+	}
+	return a.Uint() < b.Uint() && b.Uint()-1 == a.Uint()
+}
+
+func formatRange(lo, hi reflect.Value, open bool) string {
+	switch {
+	case open:
+		return formatInteger(lo) + "-"
+	case compareIntegers(lo, hi) == 0:
+		return formatInteger(lo)
+	}
+	return formatInteger(lo) + "-" + formatInteger(hi)
+}
+
+// parseRanges parses s into the slice or two-element array v using the
+// range syntax.
+func parseRanges(s string, v reflect.Value) error {
+	typ := v.Type()
+	if typ.Kind() == reflect.Array {
+		rv, err := parseRange(s, typ.Elem())
+		if err != nil {
+			return err
+		}
+		if rv.open {
+			return fmt.Errorf("open-ended range %q not supported for %s", s, typ)
+		}
+		v.Index(0).Set(rv.lo)
+		v.Index(1).Set(rv.hi)
+		return nil
+	}
+
+	rvs, err := parseRangeList(s, typ.Elem())
+	if err != nil {
+		return err
+	}
+	sl := reflect.MakeSlice(typ, 0, len(rvs))
+	for _, rv := range rvs {
+		if rv.open {
+			return fmt.Errorf("open-ended range %s not supported for %s", formatRange(rv.lo, rv.hi, true), typ)
+		}
+		for n := rv.lo; ; {
+			if sl.Len() == maxRangeElems {
+				return fmt.Errorf("ranges %q exceed %d elements", s, maxRangeElems)
+			}
+			sl = reflect.Append(sl, n)
+			if compareIntegers(n, rv.hi) == 0 {
+				break
+			}
+			next := reflect.New(typ.Elem()).Elem()
+			if isSigned(n.Kind()) {
+				next.SetInt(n.Int() + 1)
+			} else {
+				next.SetUint(n.Uint() + 1)
+			}
+			n = next
+		}
+	}
+	v.Set(sl)
+	return nil
+}
+
+// stringifyRanges is the inverse operation of parseRanges.
+func stringifyRanges(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Array {
+		if compareIntegers(v.Index(0), v.Index(1)) > 0 {
+			return "", fmt.Errorf("range bounds %v out of order", v)
+		}
+		return formatRange(v.Index(0), v.Index(1), false), nil
+	}
+
+	var parts []string
+	for i := 0; i < v.Len(); {
+		j := i
+		for j+1 < v.Len() && successor(v.Index(j), v.Index(j+1)) {
+			j++
+		}
+		parts = append(parts, formatRange(v.Index(i), v.Index(j), false))
+		i = j + 1
+	}
+	return strings.Join(parts, ","), nil
+}
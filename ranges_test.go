@@ -0,0 +1,60 @@
+package strconvert_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestRanges(t *testing.T) {
+	type Port uint16
+
+	testParse(t, "8000-8003", []int{8000, 8001, 8002, 8003}, strconvert.WithRanges())
+	testParse(t, "0-3,8", []uint{0, 1, 2, 3, 8}, strconvert.WithRanges())
+	testParse(t, "-2--1, 1", []int8{-2, -1, 1}, strconvert.WithRanges())
+	testParse(t, "8000-8010", [2]Port{8000, 8010}, strconvert.WithRanges())
+	testParse(t, "42", [2]int{42, 42}, strconvert.WithRanges())
+	testParse(t, "1-5,7,9-11,1024-", strconvert.Ranges[Port]{
+		{Lo: 1, Hi: 5}, {Lo: 7, Hi: 7}, {Lo: 9, Hi: 11}, {Lo: 1024, Hi: 1024, Open: true},
+	})
+	testParse(t, "-10--5", strconvert.Range[int]{Lo: -10, Hi: -5})
+
+	testStringify(t, []int{0, 1, 2, 3, 8, 10, 11}, "0-3,8,10-11", strconvert.WithRanges())
+	testStringify(t, []int{3, 2, 1}, "3,2,1", strconvert.WithRanges())
+	testStringify(t, []int{1, 2}, "1;2")
+	testStringify(t, [2]uint16{8000, 8010}, "8000-8010", strconvert.WithRanges())
+	testStringify(t, strconvert.Ranges[int]{{Lo: -3, Hi: -1}, {Lo: 5, Open: true}}, "-3--1,5-")
+	testStringify(t, []int64{math.MaxInt64, math.MinInt64}, "9223372036854775807,-9223372036854775808", strconvert.WithRanges())
+	testStringify(t, []uint64{math.MaxUint64, 0}, "18446744073709551615,0", strconvert.WithRanges())
+	testParse(t, "9223372036854775807,-9223372036854775808", []int64{math.MaxInt64, math.MinInt64}, strconvert.WithRanges())
+	extremes := []int64{math.MaxInt64 - 1, math.MaxInt64, math.MinInt64, math.MinInt64 + 1}
+	testStringify(t, extremes, "9223372036854775806-9223372036854775807,-9223372036854775808--9223372036854775807", strconvert.WithRanges())
+	testParse(t, "9223372036854775806-9223372036854775807,-9223372036854775808--9223372036854775807", extremes, strconvert.WithRanges())
+	testParse(t, "18446744073709551615,0", []uint64{math.MaxUint64, 0}, strconvert.WithRanges())
+
+	for _, tc := range []struct {
+		in string
+		v  any
+	}{
+		{"5-3", new([]int)},
+		{"1024-", new([]int)},
+		{"1024-", new([2]int)},
+		{"0-70000", new([]uint16)},
+		{"0-2000000", new([]int)},
+		{"a-b", new(strconvert.Ranges[int])},
+		{"9-1", new(strconvert.Range[int])},
+	} {
+		if err := strconvert.Parse(tc.in, reflect.ValueOf(tc.v).Elem(), strconvert.WithRanges()); err == nil {
+			t.Errorf("Parse(%q, <%T>, WithRanges()) = nil; want error", tc.in, tc.v)
+		}
+	}
+
+	r := strconvert.Ranges[int]{{Lo: 1, Hi: 5}, {Lo: 1024, Open: true}}
+	for n, want := range map[int]bool{0: false, 1: true, 5: true, 6: false, 1024: true, 65535: true} {
+		if got := r.Contains(n); got != want {
+			t.Errorf("%v.Contains(%d) = %t; want %t", r, n, got, want)
+		}
+	}
+}
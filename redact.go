@@ -0,0 +1,53 @@
+package strconvert
+
+import (
+	"reflect"
+	"strings"
+)
+
+// redactionMask replaces the hidden part of redacted values.
+const redactionMask = "******"
+
+// WithRedaction makes [Stringify] redact secret values, so that they can be
+// safely logged. Secret values are values of types registered using the
+// WithRedacted option and struct fields tagged with `secret:"true"`, for
+// example in tuples or headers encoded by [EncodeHeader].
+//
+// Redacted values are written as "******". If showLast is positive, the last
+// showLast characters of the value are appended to the mask, but only for
+// values that are at least twice as long, so that most of the value stays
+// hidden.
+//
+// Without this option, secret values are stringified as usual, so that they
+// can be parsed back.
+func WithRedaction(showLast int) func(*Options) {
+	return func(o *Options) {
+		o.redact = true
+		o.showLast = showLast
+	}
+}
+
+// WithRedacted registers T as a secret type, whose values are redacted when
+// stringified using the WithRedaction option.
+func WithRedacted[T any]() func(*Options) {
+	return func(o *Options) {
+		if o.redacted == nil {
+			o.redacted = make(map[reflect.Type]bool)
+		}
+		o.redacted[reflect.TypeOf((*T)(nil)).Elem()] = true
+	}
+}
+
+func redact(s string, showLast int) string {
+	if showLast <= 0 {
+		return redactionMask
+	}
+	runes := []rune(s)
+	if len(runes) < 2*showLast {
+		return redactionMask
+	}
+	var b strings.Builder
+	b.WriteString(redactionMask)
+	b.WriteString(string(runes[len(runes)-showLast:]))
+	return b.String()
+}
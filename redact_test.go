@@ -0,0 +1,44 @@
+package strconvert_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/nahojer/strconvert"
+)
+
+type Password string
+
+type Credentials struct {
+	_        struct{} `strconvert:",tuple"`
+	User     string
+	Password string `secret:"true"`
+}
+
+func TestRedaction(t *testing.T) {
+	creds := Credentials{User: "admin", Password: "hunter22"}
+	testStringify(t, creds, "admin;hunter22")
+	testStringify(t, creds, "admin;******", strconvert.WithRedaction(0))
+	testStringify(t, creds, "admin;******22", strconvert.WithRedaction(2))
+	testStringify(t, creds, "admin;******", strconvert.WithRedaction(5))
+
+	testStringify(t, Password("hunter22"), "hunter22", strconvert.WithRedacted[Password]())
+	testStringify(t, Password("hunter22"), "******", strconvert.WithRedacted[Password](), strconvert.WithRedaction(0))
+	testStringify(t, map[string]Password{"db": "hunter22", "cache": "swordfish"}, "cache:******ish;db:******r22",
+		strconvert.WithRedacted[Password](), strconvert.WithRedaction(3))
+	testStringify(t, map[string]string{"db": "hunter22"}, "******",
+		strconvert.WithRedacted[map[string]string](), strconvert.WithRedaction(0))
+
+	got, err := strconvert.GetPath(creds, "password", strconvert.WithRedaction(0))
+	if err != nil || got != "******" {
+		t.Errorf("GetPath(%v, \"password\", WithRedaction(0)) = %q, %v; want \"******\", nil", creds, got, err)
+	}
+
+	h := http.Header{}
+	if err := strconvert.EncodeHeader(h, creds, strconvert.WithRedaction(0)); err != nil {
+		t.Fatalf("EncodeHeader(%v) = %q; want nil", creds, err)
+	}
+	if got := h.Get("Password"); got != "******" {
+		t.Errorf("EncodeHeader(%v) wrote password %q; want \"******\"", creds, got)
+	}
+}
@@ -0,0 +1,94 @@
+package strconvert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WithResolver registers fn as the resolver of indirect values with the
+// given scheme. Before [Parse] converts an input that starts with the scheme
+// followed by a colon, the input is replaced by the result of calling fn
+// with the rest of the input. For example, with
+//
+//	WithResolver("file", ResolveFile)
+//
+// the input "file:/run/secrets/db_pass" is replaced by the contents of the
+// file /run/secrets/db_pass.
+//
+// No schemes are registered by default. Use WithDefaultResolvers to register
+// ResolveFile and ResolveEnv for the "file" and "env" schemes.
+//
+// References are resolved per element of slices, arrays, maps and tuples,
+// including map values, as in "db:file:/run/secrets/db_pass", since map items
+// are split at their first key separator. A backslash in front of a
+// registered scheme escapes the reference, so that `\file:/x` is parsed as
+// the literal value "file:/x".
+func WithResolver(scheme string, fn func(ref string) (string, error)) func(*Options) {
+	return func(o *Options) {
+		if scheme == "" || strings.ContainsAny(scheme, ":\\") {
+			o.savedErr = errors.Join(o.savedErr, fmt.Errorf("invalid resolver scheme %q", scheme))
+			return
+		}
+		if o.resolvers == nil {
+			o.resolvers = make(map[string]func(string) (string, error))
+		}
+		o.resolvers[scheme] = fn
+	}
+}
+
+// WithDefaultResolvers registers the built-in resolvers, ResolveFile for the
+// "file" scheme and ResolveEnv for the "env" scheme. See WithResolver.
+func WithDefaultResolvers() func(*Options) {
+	return func(o *Options) {
+		WithResolver("file", ResolveFile)(o)
+		WithResolver("env", ResolveEnv)(o)
+	}
+}
+
+// ResolveFile is a resolver for use with the WithResolver option that returns
+// the contents of the file named by ref, without a trailing newline.
+func ResolveFile(ref string) (string, error) {
+	b, err := os.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	s := strings.TrimSuffix(string(b), "\n")
+	return strings.TrimSuffix(s, "\r"), nil
+}
+
+// ResolveEnv is a resolver for use with the WithResolver option that returns
+// the value of the environment variable named by ref. It errors if the
+// variable is not set.
+func ResolveEnv(ref string) (string, error) {
+	s, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+	return s, nil
+}
+
+// resolve resolves s if it is a reference with a registered scheme, or
+// unescapes it if it is an escaped reference.
+func resolve(s string, opts *Options) (string, error) {
+	if escaped, ok := strings.CutPrefix(s, `\`); ok {
+		if scheme, _, ok := strings.Cut(escaped, ":"); ok && opts.resolvers[scheme] != nil {
+			return escaped, nil
+		}
+		return s, nil
+	}
+	scheme, ref, ok := strings.Cut(s, ":")
+	if !ok {
+		return s, nil
+	}
+	fn := opts.resolvers[scheme]
+	if fn == nil {
+		return s, nil
+	}
+	resolved, err := fn(ref)
+	if err != nil {
+		return "", fmt.Errorf("error resolving %s reference %q: %w", scheme, ref, err)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,51 @@
+package strconvert_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestResolvers(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "db_pass")
+	if err := os.WriteFile(secret, []byte("hunter22\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("STRCONVERT_TIMEOUT", "5s")
+
+	resolvers := []func(*strconvert.Options){
+		strconvert.WithResolver("file", strconvert.ResolveFile),
+		strconvert.WithResolver("env", strconvert.ResolveEnv),
+	}
+	testParse(t, "file:"+secret, "hunter22", resolvers...)
+	testParse(t, "env:STRCONVERT_TIMEOUT", 5*time.Second, resolvers...)
+	testParse(t, "plain;env:STRCONVERT_TIMEOUT", []string{"plain", "5s"}, resolvers...)
+	testParse(t, "pass:file:"+secret+";timeout:env:STRCONVERT_TIMEOUT", map[string]string{"pass": "hunter22", "timeout": "5s"}, resolvers...)
+	testParse(t, `\file:/literal`, "file:/literal", resolvers...)
+	testParse(t, `\other:value`, `\other:value`, resolvers...)
+	testParse(t, "file:"+secret, "file:"+secret)
+	testParse(t, "pass:file:"+secret+";timeout:env:STRCONVERT_TIMEOUT", map[string]string{"pass": "hunter22", "timeout": "5s"}, strconvert.WithDefaultResolvers())
+	testParse(t, "vault:db/pass", "s3cr3t", strconvert.WithResolver("vault", func(ref string) (string, error) {
+		if ref != "db/pass" {
+			return "", errors.New("not found")
+		}
+		return "s3cr3t", nil
+	}))
+
+	var s string
+	err := strconvert.Parse("env:STRCONVERT_UNSET", reflect.ValueOf(&s).Elem(), resolvers...)
+	if err == nil || !strings.Contains(err.Error(), "STRCONVERT_UNSET") {
+		t.Errorf("Parse(\"env:STRCONVERT_UNSET\") = %v; want unset variable error", err)
+	}
+
+	err = strconvert.Parse("x", reflect.ValueOf(&s).Elem(), strconvert.WithResolver("bad:", strconvert.ResolveEnv))
+	if err == nil {
+		t.Errorf("Parse(\"x\", WithResolver(\"bad:\", ...)) = nil; want error")
+	}
+}
@@ -0,0 +1,63 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestSeparators(t *testing.T) {
+	comma := strconvert.WithElementSeparatorString(", ")
+	arrow := strconvert.WithKeySeparatorString(" => ")
+
+	testParse(t, "a, b, c", []string{"a", "b", "c"}, comma)
+	testParse(t, "a::b", []string{"a", "b"}, strconvert.WithElementSeparatorString("::"), strconvert.WithKeySeparatorString("="))
+	testParse(t, "a => 1, b => 2", map[string]int{"a": 1, "b": 2}, comma, arrow)
+	testParse(t, "a=1|b=2", map[string]int{"a": 1, "b": 2}, strconvert.WithElementSeparator('|'), strconvert.WithKeySeparator('='))
+
+	// The rune options keep working as function values and with untyped
+	// integer constants.
+	var elemSep func(rune) func(*strconvert.Options) = strconvert.WithElementSeparator
+	const pipe = 0x7c
+	testParse(t, "a|b", []string{"a", "b"}, elemSep(pipe))
+	testParse(t, "x:1\n  y:2", map[string]int{"x": 1, "y": 2}, strconvert.WithElementSeparatorString("\n  "))
+
+	testParse(t, "/usr/bin:/bin", []string{"/usr/bin", "/bin"}, strconvert.WithElementSeparator(':'))
+	testStringify(t, []string{"/usr/bin", "/bin"}, "/usr/bin:/bin", strconvert.WithElementSeparator(':'))
+	testStringify(t, []string{"a", "b"}, "a, b", comma)
+	testStringify(t, map[string]int{"a": 1, "b": 2}, "a => 1, b => 2", comma, arrow)
+	testStringify(t, map[string]int{"x": 1, "y": 2}, "x:1\n  y:2", strconvert.WithElementSeparatorString("\n  "))
+
+	trim := strconvert.WithTrimSpace()
+	testParse(t, "a,b ,  c", []string{"a", "b", "c"}, comma, trim)
+	testParse(t, " a=>1 ,b   =>  2 ", map[string]int{"a": 1, "b": 2}, comma, arrow, trim)
+	testParse(t, "x: 1\n    y :2", map[string]int{"x": 1, "y": 2}, strconvert.WithElementSeparatorString("\n  "), trim)
+	testParse(t, " 10.0.0.1 ; 8080 ", Backend{Host: "10.0.0.1", Port: 8080}, trim)
+	testParse(t, " a b ", []string{"a b"}, trim)
+
+	for _, tc := range []struct {
+		name   string
+		optFns []func(*strconvert.Options)
+	}{
+		{"same", []func(*strconvert.Options){strconvert.WithElementSeparator(':')}},
+		{"contains", []func(*strconvert.Options){strconvert.WithElementSeparatorString("::")}},
+		{"contained", []func(*strconvert.Options){strconvert.WithElementSeparatorString(";"), strconvert.WithKeySeparatorString(" ; ")}},
+		{"trimmed", []func(*strconvert.Options){strconvert.WithElementSeparatorString(" : "), trim}},
+		{"empty element", []func(*strconvert.Options){strconvert.WithElementSeparatorString("")}},
+		{"empty key", []func(*strconvert.Options){strconvert.WithKeySeparatorString("")}},
+	} {
+		var m map[string]string
+		if err := strconvert.Parse("a:b", reflect.ValueOf(&m).Elem(), tc.optFns...); err == nil {
+			t.Errorf("%s: Parse(\"a:b\", <map>) = nil; want error", tc.name)
+		}
+		if _, err := strconvert.Stringify(reflect.ValueOf(m), tc.optFns...); err == nil {
+			t.Errorf("%s: Stringify(<map>) = nil; want error", tc.name)
+		}
+	}
+
+	var m map[string]string
+	if err := strconvert.Parse("a : b", reflect.ValueOf(&m).Elem(), strconvert.WithElementSeparatorString(" ; ")); err != nil {
+		t.Errorf("Parse(\"a : b\", <map>, WithElementSeparator(\" ; \")) = %q; want nil", err)
+	}
+}
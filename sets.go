@@ -0,0 +1,160 @@
+package strconvert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ApplySets applies a list of helm-style "--set" assignments to the value
+// pointed to by dst. If dst is not a non-nil pointer, ApplySets returns an
+// ErrInvalidParseArgument.
+//
+// Each entry of sets holds one or more comma-separated assignments of the
+// form path=value, as in "a.b=1,c.d[0]=x". Paths follow the syntax described
+// by [SetPath], including its escaped dots, as in
+// `nodeSelector.kubernetes\.io/role=master`, and each value is parsed into
+// the type found at its path in the same way as [Parse] would, using the
+// same options. A backslash escapes the character following it, so that
+// values may contain literal commas, equal signs, braces and backslashes, as
+// in `list=a\,b`.
+//
+// A value enclosed in braces is a list of comma-separated elements, as in
+// "hosts={a,b}", which replaces the slice at its path. The empty list is
+// "{}". Lists cannot be nested.
+//
+// Assignments are applied in order, so later assignments to the same path
+// override earlier ones.
+func ApplySets(dst any, sets []string, optFns ...func(*Options)) error {
+	for _, set := range sets {
+		for _, assignment := range splitUnescaped(set, ',') {
+			if assignment == "" {
+				continue
+			}
+			kv := splitUnescaped(assignment, '=')
+			if len(kv) < 2 {
+				return fmt.Errorf("invalid assignment %q: missing =", assignment)
+			}
+			// Escapes in the path are handled by SetPath.
+			key, value := kv[0], strings.Join(kv[1:], "=")
+			if strings.HasPrefix(value, "{") {
+				elems, ok := listElems(value)
+				if !ok {
+					return fmt.Errorf("invalid assignment %q: missing }", assignment)
+				}
+				if err := setPath(dst, key, optFns, setList(elems)); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := SetPath(dst, key, unescape(value), optFns...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// listElems returns the unescaped elements of the list value s, as in
+// "{a,b}", and reports whether s is closed by an unescaped brace.
+func listElems(s string) ([]string, bool) {
+	if len(s) < 2 || !strings.HasSuffix(s, "}") || escapedAt(s, len(s)-1) {
+		return nil, false
+	}
+	inner := s[1 : len(s)-1]
+	if inner == "" {
+		return []string{}, true
+	}
+	elems := splitUnescaped(inner, ',')
+	for i, e := range elems {
+		elems[i] = unescape(e)
+	}
+	return elems, true
+}
+
+// escapedAt reports whether the byte at index i of s is escaped by a
+// backslash.
+func escapedAt(s string, i int) bool {
+	n := 0
+	for i > 0 && s[i-1] == '\\' {
+		n++
+		i--
+	}
+	return n%2 == 1
+}
+
+// setList returns a function parsing elems into the elements of a slice,
+// replacing it.
+func setList(elems []string) func(reflect.Value, *Options) error {
+	return func(v reflect.Value, opts *Options) error {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Slice {
+			return fmt.Errorf("cannot assign list to %s", v.Type())
+		}
+		if err := targetConflicts(v.Type().Elem(), opts); err != nil {
+			return err
+		}
+		list := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, e := range elems {
+			if err := parse(e, list.Index(i), opts); err != nil {
+				return fmt.Errorf("invalid list element %q: %w", e, err)
+			}
+		}
+		v.Set(list)
+		return nil
+	}
+}
+
+// splitUnescaped splits s around each instance of sep that is neither
+// preceded by a backslash nor enclosed in braces. Escape sequences are kept
+// as is.
+func splitUnescaped(s string, sep rune) []string {
+	var (
+		parts   []string
+		start   int
+		depth   int
+		escaped bool
+	)
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '{':
+			depth++
+		case r == '}' && depth > 0:
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescape replaces every backslash escape sequence in s with the escaped
+// character.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var (
+		b       strings.Builder
+		escaped bool
+	)
+	for _, r := range s {
+		if !escaped && r == '\\' {
+			escaped = true
+			continue
+		}
+		escaped = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
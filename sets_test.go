@@ -0,0 +1,85 @@
+package strconvert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nahojer/strconvert"
+)
+
+func TestApplySets(t *testing.T) {
+	type Values struct {
+		Image struct {
+			Tag     string
+			Retries int
+		}
+		Hosts   []string
+		Timeout time.Duration
+		Env     map[string]string
+	}
+
+	var got Values
+	sets := []string{
+		`image.tag=v1.2.3,image.retries=3`,
+		`hosts[1]=b.example.com,hosts[0]=a.example.com`,
+		`env.GREETING=hello\, world,env.EXPR=a\=b,env.RAW=x=y`,
+		`timeout=1m,,timeout=2m`,
+	}
+	if err := strconvert.ApplySets(&got, sets); err != nil {
+		t.Fatalf("ApplySets(%q) = %q; want nil", sets, err)
+	}
+
+	var want Values
+	want.Image.Tag = "v1.2.3"
+	want.Image.Retries = 3
+	want.Hosts = []string{"a.example.com", "b.example.com"}
+	want.Timeout = 2 * time.Minute
+	want.Env = map[string]string{"GREETING": "hello, world", "EXPR": "a=b", "RAW": "x=y"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ApplySets(%q) mismatch (-want +got):\n%s", sets, diff)
+	}
+
+	for _, set := range []string{"image.tag", "image.retries=many", "missing=1", "image.tag={a,b}", "hosts={a,b", "hosts[50000000]=x"} {
+		if err := strconvert.ApplySets(&got, []string{set}); err == nil {
+			t.Errorf("ApplySets(%q) = nil; want error", set)
+		}
+	}
+}
+
+func TestApplySets_HelmSyntax(t *testing.T) {
+	type Values struct {
+		NodeSelector map[string]string
+		Hosts        []string
+		Ports        *[]int
+		Note         string
+	}
+
+	got := Values{Hosts: []string{"x", "y", "z"}}
+	sets := []string{
+		`nodeSelector.kubernetes\.io/role=master`,
+		`nodeSelector[topology\.kubernetes\.io/zone]=eu-1a`,
+		`hosts={a,b\,c},ports={80,443}`,
+		`note=\{literal\}`,
+	}
+	if err := strconvert.ApplySets(&got, sets); err != nil {
+		t.Fatalf("ApplySets(%q) = %q; want nil", sets, err)
+	}
+
+	want := Values{
+		NodeSelector: map[string]string{"kubernetes.io/role": "master", "topology.kubernetes.io/zone": "eu-1a"},
+		Hosts:        []string{"a", "b,c"},
+		Ports:        &[]int{80, 443},
+		Note:         "{literal}",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ApplySets(%q) mismatch (-want +got):\n%s", sets, diff)
+	}
+
+	if err := strconvert.ApplySets(&got, []string{"hosts={}"}); err != nil {
+		t.Fatalf("ApplySets(hosts={}) = %q; want nil", err)
+	}
+	if got.Hosts == nil || len(got.Hosts) != 0 {
+		t.Errorf("ApplySets(hosts={}) set %q; want empty list", got.Hosts)
+	}
+}
@@ -0,0 +1,129 @@
+package strconvert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WithShellWords makes [Parse] and [Stringify] split and join the elements
+// of slices, arrays, maps and tuples as words following the quoting rules of
+// the POSIX shell, rather than using the element separator, as in
+//
+//	--flag "a b" 'c d' e\ f
+//
+// Words are separated by white space. Single quotes preserve the enclosed
+// characters literally, double quotes preserve them except for backslashes
+// escaping $, `, ", \ and newlines, and unquoted backslashes escape the next
+// character. Nothing is ever expanded or executed: $ and ` are taken
+// literally. The empty string has no words.
+//
+// Stringify quotes words containing any other characters than letters,
+// digits and @%+=:,./_- using single quotes.
+//
+// WithShellWords cannot be combined with WithCSVElements.
+func WithShellWords() func(*Options) {
+	return func(o *Options) {
+		o.shellWords = true
+	}
+}
+
+// splitShellWords splits s into words.
+func splitShellWords(s string) ([]string, error) {
+	words := []string{}
+	var (
+		word   strings.Builder
+		inWord bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+
+		case c == '\\':
+			if i+1 == len(s) {
+				return nil, errors.New("invalid shell words: trailing backslash")
+			}
+			i++
+			// A backslash followed by a newline continues the line.
+			if s[i] != '\n' {
+				word.WriteByte(s[i])
+				inWord = true
+			}
+
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid shell words %q: missing closing '", s)
+			}
+			word.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+
+		case c == '"':
+			n, err := readDoubleQuoted(s[i+1:], &word)
+			if err != nil {
+				return nil, fmt.Errorf("invalid shell words %q: %w", s, err)
+			}
+			i += n
+			inWord = true
+
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// readDoubleQuoted writes the double-quoted part at the start of s, following
+// the opening quote, to word and returns the number of bytes read, including
+// the closing quote.
+func readDoubleQuoted(s string, word *strings.Builder) (int, error) {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return i + 1, nil
+		case '\\':
+			if i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) >= 0 {
+				i++
+				if s[i] != '\n' {
+					word.WriteByte(s[i])
+				}
+				continue
+			}
+			word.WriteByte(c)
+		default:
+			word.WriteByte(c)
+		}
+	}
+	return 0, errors.New(`missing closing "`)
+}
+
+// joinShellWords joins words, quoting them as needed. It is the inverse
+// operation of splitShellWords.
+func joinShellWords(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = quoteShellWord(w)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func quoteShellWord(w string) string {
+	if w != "" && strings.Trim(w, shellSafeChars) == "" {
+		return w
+	}
+	return "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+}
+
+// shellSafeChars are the characters of words that need no quoting.
+const shellSafeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%+=:,./_-"
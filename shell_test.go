@@ -0,0 +1,42 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestShellWords(t *testing.T) {
+	sh := strconvert.WithShellWords()
+
+	testParse(t, `--flag "a b" 'c d' e\ f`, []string{"--flag", "a b", "c d", "e f"}, sh)
+	testParse(t, "  a \t b\n c  ", []string{"a", "b", "c"}, sh)
+	testParse(t, "", []string{}, sh)
+	testParse(t, `'' ""`, []string{"", ""}, sh)
+	testParse(t, `a'b'"c"d`, []string{"abcd"}, sh)
+	testParse(t, `'$HOME' "$(rm -rf /)" \$x`, []string{"$HOME", "$(rm -rf /)", "$x"}, sh)
+	testParse(t, `"a \"b\" \\ \$ \x"`, []string{`a "b" \ $ \x`}, sh)
+	testParse(t, `'a\b'`, []string{`a\b`}, sh)
+	testParse(t, "a\\\nb \"c\\\nd\"", []string{"ab", "cd"}, sh)
+	testParse(t, `1 2 -3`, [3]int{1, 2, -3}, sh)
+	testParse(t, `'a:x y' b:z`, map[string]string{"a": "x y", "b": "z"}, sh)
+	testParse(t, `'10.0.0.1' 8080`, Backend{Host: "10.0.0.1", Port: 8080}, sh)
+
+	testStringify(t, []string{"--flag", "a b", "c'd", ""}, `--flag 'a b' 'c'\''d' ''`, sh)
+	testStringify(t, []string{"$HOME", "a/b.c", "k=v"}, `'$HOME' a/b.c k=v`, sh)
+	testStringify(t, []int{1, -2}, "1 -2", sh)
+	testStringify(t, map[string]string{"a": "x y"}, `'a:x y'`, sh)
+
+	for _, in := range []string{`'a`, `"a`, `a\`, `"a\"`} {
+		var s []string
+		if err := strconvert.Parse(in, reflect.ValueOf(&s).Elem(), sh); err == nil {
+			t.Errorf("Parse(%q, <slice>, WithShellWords()) = nil; want error", in)
+		}
+	}
+
+	var s []string
+	if err := strconvert.Parse("a", reflect.ValueOf(&s).Elem(), sh, strconvert.WithCSVElements()); err == nil {
+		t.Errorf("Parse(\"a\", <slice>, WithShellWords(), WithCSVElements()) = nil; want error")
+	}
+}
@@ -2,8 +2,8 @@ package strconvert
 
 import (
 	"fmt"
+	"io/fs"
 	"reflect"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -19,30 +19,54 @@ import (
 //   - ~string
 //   - ~int, ~int8, ~int16, ~int32, ~int64
 //   - [time.Duration]
+//   - [net.IPNet], [net.HardwareAddr], [net.TCPAddr] and [net.UDPAddr]
+//   - [fs.FileMode]
 //   - ~uint, ~uint8, ~uint16, ~uint32, ~uint64
 //   - ~float32, ~float64
 //   - ~complex64, ~complex128
 //   - ~bool
-//   - Any pointer to the above types
+//   - Pointers of any depth to the above types, where stringifiers
+//     registered for a type also apply to pointers to it
 //   - slices, arrays and maps of any of the above types
+//   - [OrderedMap] types of any of the above types
+//   - []byte, named byte slice types and byte arrays (see [WithBytesEncoding])
+//   - [UUID]
+//   - Tuple structs whose fields are any of the above types (see [Parse])
 //
 // Stringify errors for any unsupported type. More types may be be supported in
 // the future.
 //
+// File modes are formatted in octal notation with a leading zero, as in
+// "0644" or "04755", unless they have any file type bits set, in which case
+// they are formatted as described by [fs.FileMode.String].
+//
 // Float and complex types are formatted using the format byte 'f'
 // and precision -1. See the documentation for [strconv.FormatFloat].
 // Override this behaviour by registering custom stringifiers.
 //
-// Map values are sorted before formatted into the final string representation,
-// ensuring consistent and predictable output. By default, keys and values are
+// Map entries are sorted by key before formatted into the final string
+// representation, ensuring consistent and predictable output. Keys are
+// ordered using the comparator registered for their type using the
+// WithKeyComparator option, if any, by value if they are numbers, strings,
+// booleans or [time.Time], and else by their string representation.
+// [OrderedMap] entries keep their insertion order. By default, keys and values are
 // separated using colons (":") and key, value pairs are separated using
 // semicolons (";").
 //
 // By default, slice and array elements are separated using semicolons (";").
+// Tuple fields are separated in the same way, in declaration order.
+//
+// Nil pointers are stringified as the empty string, unless the WithNilLiteral
+// option is used. Secret values are redacted when using the WithRedaction
+// option.
 func Stringify(v reflect.Value, optFns ...func(*Options)) (string, error) {
-	opts := buildOptions(optFns)
-	if opts.savedErr != nil {
-		return "", opts.savedErr
+	var typ reflect.Type
+	if v.IsValid() {
+		typ = v.Type()
+	}
+	opts, err := optionsFor(optFns, typ)
+	if err != nil {
+		return "", err
 	}
 	return stringify(v, &opts)
 }
@@ -50,6 +74,20 @@ func Stringify(v reflect.Value, optFns ...func(*Options)) (string, error) {
 func stringify(v reflect.Value, opts *Options) (string, error) {
 	typ := v.Type()
 
+	if opts.nilLitSet && isNilable(typ) && v.IsNil() {
+		return opts.nilLit, nil
+	}
+
+	if opts.redact && (opts.secret || opts.redacted[typ]) {
+		plain := *opts
+		plain.redact = false
+		s, err := stringify(v, &plain)
+		if err != nil {
+			return "", err
+		}
+		return redact(s, opts.showLast), nil
+	}
+
 	if fn, ok := opts.funcs[typ]; ok {
 		out := fn.Call([]reflect.Value{v})
 		err, _ := out[1].Interface().(error)
@@ -59,6 +97,15 @@ func stringify(v reflect.Value, opts *Options) (string, error) {
 		return out[0].String(), nil
 	}
 
+	if typ.Kind() == reflect.Ptr && v.IsNil() {
+		return "", nil
+	}
+
+	if typ.Kind() == reflect.Ptr && hasFunc(typ.Elem(), opts) {
+		// Stringifiers of the element type take precedence over marshalers.
+		return stringify(v.Elem(), opts)
+	}
+
 	if m := textMarshaler(v); m != nil {
 		b, err := m.MarshalText()
 		if err != nil {
@@ -76,11 +123,40 @@ func stringify(v reflect.Value, opts *Options) (string, error) {
 	}
 
 	if typ.Kind() == reflect.Ptr {
-		typ = typ.Elem()
-		if v.IsNil() {
-			return "", nil
-		}
-		v = v.Elem()
+		// Look for stringifiers and marshalers of the element type as well.
+		return stringify(v.Elem(), opts)
+	}
+
+	if s, ok := stringifyNet(v); ok {
+		return s, nil
+	}
+
+	if opts.ranges && isRangeType(typ) {
+		return stringifyRanges(v)
+	}
+
+	if scale, ok := opts.fixed[typ]; ok {
+		return formatFixed(v.Int(), scale), nil
+	}
+
+	if opts.quantities && isQuantityType(typ) {
+		return stringifyQuantity(v), nil
+	}
+
+	if typ == fileModeType {
+		return formatFileMode(fs.FileMode(v.Uint())), nil
+	}
+
+	if opts.emptyLitSet && isCollection(typ) && collectionLen(v) == 0 {
+		return opts.emptyLit, nil
+	}
+
+	if isOrderedMap(typ) {
+		return stringifyOrderedMap(v, opts)
+	}
+
+	if isBytes(typ) {
+		return stringifyBytes(v, opts), nil
 	}
 
 	switch typ.Kind() {
@@ -112,10 +188,6 @@ func stringify(v reflect.Value, opts *Options) (string, error) {
 		return strconv.FormatBool(v.Bool()), nil
 
 	case reflect.Slice, reflect.Array:
-		if typ.Elem().Kind() == reflect.Uint8 {
-			return string(v.Bytes()), nil
-		}
-
 		strSlice := make([]string, v.Len())
 		for i := 0; i < v.Len(); i++ {
 			s, err := stringify(v.Index(i), opts)
@@ -124,29 +196,75 @@ func stringify(v reflect.Value, opts *Options) (string, error) {
 			}
 			strSlice[i] = s
 		}
-		return strings.Join(strSlice, string(opts.elemSep)), nil
+		return joinElems(strSlice, opts), nil
 
 	case reflect.Map:
-		strSlice := make([]string, v.Len())
-		for i, k := range v.MapKeys() {
-			sk, err := stringify(k, opts)
+		entries := make([]mapEntry, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			e, err := stringifyEntry(iter.Key(), iter.Value(), opts)
 			if err != nil {
-				return "", fmt.Errorf("error stringifying key %v of map: %w", k, err)
+				return "", err
 			}
+			entries = append(entries, e)
+		}
+		// Sort to get predictable output.
+		sortEntries(entries, opts)
+		return joinEntries(entries, opts), nil
 
-			v := v.MapIndex(k)
-			sv, err := stringify(v, opts)
+	case reflect.Struct:
+		if !isTuple(typ) {
+			break
+		}
+		fields := structFields(typ)
+		strSlice := make([]string, len(fields))
+		for i, f := range fields {
+			s, err := stringify(v.FieldByIndex(f.Index), fieldOptions(f, opts))
 			if err != nil {
-				return "", fmt.Errorf("error stringifying map value with key %s: %w", sk, err)
+				return "", fmt.Errorf("error stringifying tuple field %s: %w", f.Name, err)
 			}
-
-			strSlice[i] = sk + string(opts.keySep) + sv
+			strSlice[i] = s
 		}
-		// Sort to get predictable output.
-		sort.Strings(strSlice)
-
-		return strings.Join(strSlice, string(opts.elemSep)), nil
+		return joinElems(strSlice, opts), nil
 	}
 
 	return "", fmt.Errorf("unsupported field type %s", typ.Kind().String())
 }
+
+// stringifyEntry stringifies the key, value pair k, v of a map.
+func stringifyEntry(k, v reflect.Value, opts *Options) (mapEntry, error) {
+	sk, err := stringify(k, opts)
+	if err != nil {
+		return mapEntry{}, fmt.Errorf("error stringifying key %v of map: %w", k, err)
+	}
+	if sep := opts.effectiveSep(opts.keySep); !opts.csv && strings.Contains(sk, sep) {
+		// The key would be split at the key separator when parsed.
+		return mapEntry{}, fmt.Errorf("key %q of map contains key separator %q", sk, sep)
+	}
+	sv, err := stringify(v, opts)
+	if err != nil {
+		return mapEntry{}, fmt.Errorf("error stringifying map value with key %s: %w", sk, err)
+	}
+	return mapEntry{key: k, sk: sk, s: quoteKey(sk, opts) + opts.keySep + sv}, nil
+}
+
+// joinEntries joins the stringified key, value pairs of a map in order.
+func joinEntries(entries []mapEntry, opts *Options) string {
+	strSlice := make([]string, len(entries))
+	for i, e := range entries {
+		strSlice[i] = e.s
+	}
+	return joinElems(strSlice, opts)
+}
+
+// joinElems joins the elements of a slice, array, map or tuple into a single
+// string. It is the inverse operation of splitElems.
+func joinElems(elems []string, opts *Options) string {
+	if opts.csv {
+		return joinCSV(elems, opts)
+	}
+	if opts.shellWords {
+		return joinShellWords(elems)
+	}
+	return strings.Join(elems, opts.elemSep)
+}
@@ -63,6 +63,21 @@ func TestStringify(t *testing.T) {
 		testStringify(t, [10]string{"item1", "item2"}, "item1;item2;;;;;;;;")
 	})
 
+	t.Run("tuple", func(t *testing.T) {
+		testStringify(t, Backend{Host: "10.0.0.1", Port: 8080, Weight: 0.5}, "10.0.0.1;8080;0.5")
+		testStringify(t, Backend{Host: "a"}, "a|0|0", strconvert.WithElementSeparator('|'))
+	})
+
+	t.Run("map keys", func(t *testing.T) {
+		m := map[string]string{"http://x": "1"}
+		if got, err := strconvert.Stringify(reflect.ValueOf(m)); err == nil {
+			t.Errorf("Stringify(%v) = %q, nil; want key separator error", m, got)
+		}
+		testStringify(t, map[string]string{"a": "http://x"}, "a:http://x")
+		testStringify(t, m, `"""http://x"":1"`, strconvert.WithCSVElements())
+		testParse(t, `"""http://x"":1"`, m, strconvert.WithCSVElements())
+	})
+
 	t.Run("text marshaler", func(t *testing.T) {
 		testStringify(t, &TextStruct{"some text"}, "some text")
 	})
@@ -88,6 +103,36 @@ func TestStringify(t *testing.T) {
 		}))
 	})
 
+	t.Run("pointers", func(t *testing.T) {
+		n := 42
+		pn := &n
+		testStringify(t, &pn, "42")
+		testStringify(t, (**int)(nil), "")
+		testStringify(t, &[]int{1, 2}, "1;2")
+		testStringify(t, &map[string]int{"a": 1}, "a:1")
+		testStringify(t, (*strconvert.UUID)(nil), "")
+		pt := &TextStruct{"some text"}
+		testStringify(t, &pt, "some text")
+
+		x := 3.14159
+		px := &x
+		stringifier := strconvert.WithStringifier(func(f float64) (string, error) {
+			return strconv.FormatFloat(f, 'E', 3, 64), nil
+		})
+		testStringify(t, &x, "3.142E+00", stringifier)
+		testStringify(t, &px, "3.142E+00", stringifier)
+		testStringify(t, []*float64{&x, nil}, "3.142E+00;", stringifier)
+
+		// Stringifiers take precedence over the marshalers of time.Time.
+		tm := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		ptm := &tm
+		date := strconvert.WithStringifier(func(t time.Time) (string, error) {
+			return t.Format("2006-01-02"), nil
+		})
+		testStringify(t, &tm, "2024-01-02", date)
+		testStringify(t, &ptm, "2024-01-02", date)
+	})
+
 	t.Run("bad stringifier types", func(t *testing.T) {
 		testBadStringifier(t, func(any) (string, error) { return "", nil })
 		testBadStringifier(t, func(io.Writer) (string, error) { return "", nil })
@@ -0,0 +1,68 @@
+package strconvert
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// UUID is a universally unique identifier as defined in RFC 9562, formatted
+// as "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx".
+type UUID [16]byte
+
+// ParseUUID parses a UUID in canonical form, as in
+// "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", enclosed in braces, as in
+// "{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}", in URN form, as in
+// "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", or as 32 hexadecimal
+// digits without hyphens. Hexadecimal digits may be upper or lower case.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	in := s
+	if len(s) > 9 && strings.EqualFold(s[:9], "urn:uuid:") {
+		s = s[9:]
+	} else if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = s[1 : len(s)-1]
+	}
+	switch len(s) {
+	case 32:
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, fmt.Errorf("invalid UUID %q", in)
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	default:
+		return u, fmt.Errorf("invalid UUID %q", in)
+	}
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return u, fmt.Errorf("invalid UUID %q", in)
+	}
+	return u, nil
+}
+
+// String returns u in canonical form, with lower case hexadecimal digits.
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[:8], u[:4])
+	hex.Encode(b[9:13], u[4:6])
+	hex.Encode(b[14:18], u[6:8])
+	hex.Encode(b[19:23], u[8:10])
+	hex.Encode(b[24:], u[10:])
+	b[8], b[13], b[18], b[23] = '-', '-', '-', '-'
+	return string(b[:])
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler]. See ParseUUID for the
+// accepted forms.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
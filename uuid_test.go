@@ -0,0 +1,47 @@
+package strconvert_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nahojer/strconvert"
+)
+
+func TestUUID(t *testing.T) {
+	want := strconvert.UUID{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}
+	for _, in := range []string{
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}",
+		"urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"URN:UUID:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"f81d4fae7dec11d0a76500a0c91e6bf6",
+	} {
+		testParse(t, in, want)
+		testParse(t, in, [16]byte(want))
+	}
+
+	testParse(t, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", &want)
+
+	testStringify(t, want, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	testStringify(t, [16]byte(want), "f81d4fae7dec11d0a76500a0c91e6bf6")
+	testIdentity(t, want)
+
+	for _, in := range []string{
+		"",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf",
+		"f81d4fae7-dec-11d0-a765-00a0c91e6bf6",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"urn:uuid:{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}",
+		"g81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+	} {
+		var u strconvert.UUID
+		if err := strconvert.Parse(in, reflect.ValueOf(&u).Elem()); err == nil {
+			t.Errorf("Parse(%q, <UUID>) = nil; want error", in)
+		}
+		var a [16]byte
+		if err := strconvert.Parse(in, reflect.ValueOf(&a).Elem()); err == nil {
+			t.Errorf("Parse(%q, <[16]byte>) = nil; want error", in)
+		}
+	}
+}
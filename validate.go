@@ -0,0 +1,347 @@
+package strconvert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError describes a value violating a validation rule.
+type ValidationError struct {
+	// Path to the offending value, using the syntax of SetPath. Empty for the
+	// value passed to Parse or Validate itself.
+	Path string
+	// Rule that was violated, such as "max=65535".
+	Rule string
+	// Msg describes the violation.
+	Msg string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Msg
+	}
+	return e.Path + ": " + e.Msg
+}
+
+// Validate checks v against the validation rules declared in the "validate"
+// struct tags of its fields and returns the joined *ValidationError of every
+// violation. Nested structs and pointers to structs are validated
+// recursively. Rules set using the WithValidation option apply to v itself.
+//
+// Rules are comma-separated, with arguments following an equal sign:
+//
+//	Port    int           `validate:"min=1,max=65535"`
+//	Timeout time.Duration `validate:"nonzero,max=1m"`
+//	Level   string        `validate:"oneof=debug info warn error"`
+//	Hosts   []string      `validate:"min=1,dive,regexp=^[a-z.]+$"`
+//
+// The following rules are supported:
+//
+//   - nonzero: the value is not the zero value, or not empty for strings,
+//     slices and maps; arrays must have a non-zero element
+//   - min=x, max=x: numbers (including [time.Duration]) are at least or at
+//     most x, parsed into the type of the value; strings, slices, arrays and
+//     maps have a length of at least or at most x
+//   - len=n: strings, slices, arrays and maps have a length of exactly n
+//   - oneof=a b c: the stringified value is one of the space-separated
+//     options
+//   - regexp=re: the stringified value matches the regular expression re
+//   - dive: the remaining rules apply to every element of a slice or array,
+//     or to every value of a map, instead of to the value itself
+//
+// Lengths of strings are counted in runes. Commas within arguments must be
+// escaped with a backslash. Rules other than nonzero are not checked against
+// nil pointers. The options are used to parse the bounds of min and max and
+// to stringify values for oneof and regexp, and the separators are only
+// checked against the values converted in this way.
+func Validate(v any, optFns ...func(*Options)) error {
+	opts, err := optionsFor(optFns)
+	if err != nil {
+		return err
+	}
+	return validate(reflect.ValueOf(v), opts.rules, &opts)
+}
+
+// WithValidation sets the validation rules that [Parse] checks the parsed
+// value against, and that [Validate] checks its argument against. See
+// [Validate] for the rule syntax.
+//
+// Parse always checks the rules declared in struct tags of parsed tuples.
+func WithValidation(rules string) func(*Options) {
+	return func(o *Options) {
+		rs, err := parseRules(rules)
+		if err != nil {
+			o.savedErr = errors.Join(o.savedErr, err)
+			return
+		}
+		o.rules = rs
+	}
+}
+
+type rule struct {
+	name, arg string
+}
+
+func (r rule) String() string {
+	if r.arg == "" {
+		return r.name
+	}
+	return r.name + "=" + r.arg
+}
+
+func parseRules(s string) ([]rule, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var rules []rule
+	for _, part := range splitUnescaped(s, ',') {
+		name, arg, _ := strings.Cut(strings.ReplaceAll(part, `\,`, ","), "=")
+		r := rule{name: strings.TrimSpace(name), arg: arg}
+		switch r.name {
+		case "nonzero", "dive":
+		case "min", "max", "len", "oneof":
+			if r.arg == "" {
+				return nil, fmt.Errorf("validation rule %s requires an argument", r.name)
+			}
+		case "regexp":
+			if _, err := regexp.Compile(r.arg); err != nil {
+				return nil, fmt.Errorf("invalid validation rule %s: %w", r, err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown validation rule %q", r.name)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+func validate(v reflect.Value, rules []rule, opts *Options) error {
+	if !v.IsValid() {
+		return nil
+	}
+	return errors.Join(validateValue(v, rules, "", opts, map[visit]bool{})...)
+}
+
+// validateValue checks v and its fields against rules and the rules of
+// their struct tags. seen holds the pointers along path, which are not
+// followed again, so that cyclic values are validated once.
+func validateValue(v reflect.Value, rules []rule, path string, opts *Options, seen map[visit]bool) []error {
+	var errs []error
+	for i, r := range rules {
+		if r.name == "dive" {
+			return append(errs, validateElems(v, rules[i+1:], path, opts, seen)...)
+		}
+		msg, err := checkRule(v, r, opts)
+		if err != nil {
+			return append(errs, fmt.Errorf("error validating %s: %w", pathOrValue(path), err))
+		}
+		if msg != "" {
+			errs = append(errs, &ValidationError{Path: path, Rule: r.String(), Msg: msg})
+		}
+	}
+
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		k := visit{v.Pointer(), v.Type()}
+		if seen[k] {
+			return errs
+		}
+		seen[k] = true
+		defer delete(seen, k)
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return errs
+	}
+	for _, f := range structFields(v.Type()) {
+		fieldRules, err := parseRules(f.Tag.Get("validate"))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error validating %s: %w", pathOrValue(joinPath(path, f.Name)), err))
+			continue
+		}
+		errs = append(errs, validateValue(v.FieldByIndex(f.Index), fieldRules, joinPath(path, f.Name), opts, seen)...)
+	}
+	return errs
+}
+
+func validateElems(v reflect.Value, rules []rule, path string, opts *Options, seen map[visit]bool) []error {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	var errs []error
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			errs = append(errs, validateValue(v.Index(i), rules, path+"["+strconv.Itoa(i)+"]", opts, seen)...)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			sk, err := stringify(k, opts)
+			if err != nil {
+				sk = fmt.Sprint(k)
+			}
+			errs = append(errs, validateValue(v.MapIndex(k), rules, path+"["+sk+"]", opts, seen)...)
+		}
+	default:
+		errs = append(errs, fmt.Errorf("error validating %s: cannot dive into %s", pathOrValue(path), v.Type()))
+	}
+	return errs
+}
+
+// checkRule checks v against r and returns a description of the violation,
+// or an empty string if v satisfies r.
+func checkRule(v reflect.Value, r rule, opts *Options) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			if r.name == "nonzero" {
+				return "must not be nil", nil
+			}
+			return "", nil
+		}
+		return checkRule(v.Elem(), r, opts)
+	}
+
+	n, hasLen := length(v)
+	switch r.name {
+	case "nonzero":
+		zero := v.IsZero()
+		if hasLen && v.Kind() != reflect.Array {
+			// Strings, slices and maps are zero if empty.
+			zero = n == 0
+		}
+		if zero {
+			return "must not be zero", nil
+		}
+
+	case "len":
+		want, err := strconv.Atoi(r.arg)
+		if err != nil || !hasLen {
+			return "", fmt.Errorf("rule %s is invalid for %s", r, v.Type())
+		}
+		if n != want {
+			return fmt.Sprintf("must have length %d, has %d", want, n), nil
+		}
+
+	case "min", "max":
+		c, err := compareBound(v, n, hasLen, r.arg, opts)
+		if err != nil {
+			return "", fmt.Errorf("rule %s is invalid for %s: %w", r, v.Type(), err)
+		}
+		switch {
+		case r.name == "min" && c < 0 && hasLen:
+			return fmt.Sprintf("must have length at least %s, has %d", r.arg, n), nil
+		case r.name == "min" && c < 0:
+			return "must be at least " + r.arg, nil
+		case r.name == "max" && c > 0 && hasLen:
+			return fmt.Sprintf("must have length at most %s, has %d", r.arg, n), nil
+		case r.name == "max" && c > 0:
+			return "must be at most " + r.arg, nil
+		}
+
+	case "oneof":
+		s, err := stringifyChecked(v, opts)
+		if err != nil {
+			return "", err
+		}
+		options := strings.Fields(r.arg)
+		for _, o := range options {
+			if s == o {
+				return "", nil
+			}
+		}
+		return fmt.Sprintf("must be one of %s, is %q", strings.Join(options, ", "), s), nil
+
+	case "regexp":
+		re, err := regexp.Compile(r.arg)
+		if err != nil {
+			return "", err
+		}
+		s, err := stringifyChecked(v, opts)
+		if err != nil {
+			return "", err
+		}
+		if !re.MatchString(s) {
+			return fmt.Sprintf("must match %s, is %q", r.arg, s), nil
+		}
+	}
+	return "", nil
+}
+
+// stringifyChecked stringifies v for the oneof and regexp rules, after
+// checking the separators against the type of v, as Validate only converts
+// the values it checks against those rules.
+func stringifyChecked(v reflect.Value, opts *Options) (string, error) {
+	if err := targetConflicts(v.Type(), opts); err != nil {
+		return "", err
+	}
+	return stringify(v, opts)
+}
+
+// compareBound compares v (or its length n, if hasLen is true) with bound and
+// returns -1, 0 or +1 depending on whether v is less than, equal to or
+// greater than bound.
+func compareBound(v reflect.Value, n int, hasLen bool, bound string, opts *Options) (int, error) {
+	if hasLen {
+		b, err := strconv.Atoi(bound)
+		if err != nil {
+			return 0, err
+		}
+		return compare(n, b), nil
+	}
+
+	b := reflect.New(v.Type()).Elem()
+	if err := targetConflicts(v.Type(), opts); err != nil {
+		return 0, err
+	}
+	if err := parse(bound, b, opts); err != nil {
+		return 0, err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compare(v.Int(), b.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compare(v.Uint(), b.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return compare(v.Float(), b.Float()), nil
+	}
+	return 0, errors.New("not a number or collection")
+}
+
+func compare[T int | int64 | uint64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func length(v reflect.Value) (int, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(v.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func pathOrValue(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return path
+}
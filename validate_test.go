@@ -0,0 +1,125 @@
+package strconvert_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nahojer/strconvert"
+)
+
+type ValidatedConfig struct {
+	Port    int               `validate:"min=1,max=65535"`
+	Timeout time.Duration     `validate:"nonzero,max=1m"`
+	Level   string            `validate:"oneof=debug info warn error"`
+	Hosts   []string          `validate:"min=1,dive,regexp=^[a-z.]+$"`
+	Limits  map[string]uint8  `validate:"dive,max=100"`
+	Name    string            `validate:"len=3"`
+	Backup  *ValidatedBackend `validate:"nonzero"`
+}
+
+type ValidatedBackend struct {
+	_      struct{} `strconvert:",tuple"`
+	Host   string   `validate:"nonzero"`
+	Weight float64  `validate:"min=0,max=1"`
+}
+
+func TestValidate(t *testing.T) {
+	valid := ValidatedConfig{
+		Port:    8080,
+		Timeout: 30 * time.Second,
+		Level:   "info",
+		Hosts:   []string{"example.com"},
+		Limits:  map[string]uint8{"cpu": 100},
+		Name:    "ünï",
+		Backup:  &ValidatedBackend{Host: "backup", Weight: 0.5},
+	}
+	if err := strconvert.Validate(valid); err != nil {
+		t.Errorf("Validate(%+v) = %q; want nil", valid, err)
+	}
+
+	invalid := ValidatedConfig{
+		Port:   70000,
+		Level:  "trace",
+		Hosts:  []string{"example.com", "Example.com"},
+		Limits: map[string]uint8{"cpu": 101},
+		Name:   "toolong",
+		Backup: &ValidatedBackend{Weight: 2},
+	}
+	err := strconvert.Validate(&invalid)
+	if err == nil {
+		t.Fatalf("Validate(%+v) = nil; want error", invalid)
+	}
+	wantPaths := []string{"Port", "Timeout", "Level", "Hosts[1]", "Limits[cpu]", "Name", "Backup.Host", "Backup.Weight"}
+	var gotPaths []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var verr *strconvert.ValidationError
+		if !errors.As(e, &verr) {
+			t.Fatalf("unexpected error %q", e)
+		}
+		gotPaths = append(gotPaths, verr.Path)
+	}
+	if !reflect.DeepEqual(gotPaths, wantPaths) {
+		t.Errorf("Validate() violations at %q; want %q", gotPaths, wantPaths)
+	}
+
+	type Node struct {
+		Name string `validate:"nonzero"`
+		Next *Node
+	}
+	n := &Node{}
+	n.Next = &Node{Name: "b", Next: n}
+	if err := strconvert.Validate(n); err == nil || !strings.Contains(err.Error(), "Name") {
+		t.Errorf("Validate(<cyclic>) = %v; want violation of Name", err)
+	}
+	n.Name = "a"
+	if err := strconvert.Validate(n); err != nil {
+		t.Errorf("Validate(<cyclic>) = %q; want nil", err)
+	}
+
+	var arr struct {
+		IDs [3]int `validate:"nonzero"`
+	}
+	if err := strconvert.Validate(arr); err == nil {
+		t.Errorf("Validate(<zero array>) = nil; want error")
+	}
+	arr.IDs[1] = 1
+	if err := strconvert.Validate(arr); err != nil {
+		t.Errorf("Validate(<non-zero array>) = %q; want nil", err)
+	}
+
+	if err := strconvert.Validate(ValidatedConfig{}, strconvert.WithValidation("unknown")); err == nil {
+		t.Errorf("Validate(WithValidation(\"unknown\")) = nil; want error")
+	}
+}
+
+func TestParseValidation(t *testing.T) {
+	testParse(t, "443", 443, strconvert.WithValidation("min=1,max=65535"))
+	testParse(t, "a;b", []string{"a", "b"}, strconvert.WithValidation(`len=2,dive,regexp=^[a-z]{1\,3}$`))
+	testParse(t, "backup;1", ValidatedBackend{Host: "backup", Weight: 1})
+
+	for _, tc := range []struct {
+		in    string
+		v     any
+		rules string
+	}{
+		{"0", new(int), "min=1"},
+		{"2m", new(time.Duration), "max=1m"},
+		{"a;bb", new([]string), "dive,len=1"},
+		{";0.5", new(ValidatedBackend), ""},
+	} {
+		err := strconvert.Parse(tc.in, reflect.ValueOf(tc.v).Elem(), strconvert.WithValidation(tc.rules))
+		var verr *strconvert.ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("Parse(%q, WithValidation(%q)) = %v; want *ValidationError", tc.in, tc.rules, err)
+		}
+	}
+
+	var port int
+	err := strconvert.Parse("1", reflect.ValueOf(&port).Elem(), strconvert.WithValidation("min=one"))
+	if err == nil || !strings.Contains(err.Error(), "min=one") {
+		t.Errorf("Parse(\"1\", WithValidation(\"min=one\")) = %v; want invalid rule error", err)
+	}
+}